@@ -1,28 +1,161 @@
 package fpgo
 
 import (
+	"context"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 )
 
 var ErrActorAskTimeout = fmt.Errorf("ErrActorAskTimeout")
 
+// ErrActorNameInvalid The name is empty, contains "/" or starts with "$"(reserved for the generated names)
+var ErrActorNameInvalid = fmt.Errorf("ErrActorNameInvalid")
+
+// ErrActorNameConflict The path has been taken by another Actor
+var ErrActorNameConflict = fmt.Errorf("ErrActorNameConflict")
+
+// ErrActorNotFound No Actor is found by the path
+var ErrActorNotFound = fmt.Errorf("ErrActorNotFound")
+
+var (
+	actorIDLock sync.Mutex
+	actorIDLast time.Time
+	actorSeq    uint64
+)
+
+// newActorID Generate a unique & increasing ID even if time.Now() doesn't change
+func newActorID() time.Time {
+	actorIDLock.Lock()
+	defer actorIDLock.Unlock()
+
+	id := time.Now()
+	if !id.After(actorIDLast) {
+		id = actorIDLast.Add(1)
+	}
+	actorIDLast = id
+	return id
+}
+
+// newActorName Generate a unique name for the Actor spawned without a name
+func newActorName() string {
+	actorIDLock.Lock()
+	defer actorIDLock.Unlock()
+
+	actorSeq++
+	return "$" + strconv.FormatUint(actorSeq, 10)
+}
+
+func isActorNameValid(name string) bool {
+	return name != "" && !strings.Contains(name, "/") && !strings.HasPrefix(name, "$")
+}
+
 // ActorHandle A target could send messages
 type ActorHandle[T any] interface {
-	Send(message T)
+	Send(message T) error
+}
+
+// SupervisorStrategyType The way to apply the directive to the children when one of them fails
+type SupervisorStrategyType int
+
+const (
+	// SupervisorOneForOne Only the failed child is restarted/stopped
+	SupervisorOneForOne SupervisorStrategyType = iota
+	// SupervisorOneForAll All children are restarted/stopped
+	SupervisorOneForAll
+	// SupervisorRestForOne The failed child and the children spawned after it are restarted/stopped
+	SupervisorRestForOne
+)
+
+// SupervisorDirective The decision made by the supervisor about the failed children
+type SupervisorDirective int
+
+const (
+	// SupervisorRestart Restart the children(their context & behavior are reset to the initial ones)
+	SupervisorRestart SupervisorDirective = iota
+	// SupervisorStop Stop(Close) the children
+	SupervisorStop
+)
+
+// SupervisorStrategy The supervision of the children of an Actor
+type SupervisorStrategy struct {
+	Type SupervisorStrategyType
+	// MaxRestarts The max restarts of a child within the Window(unlimited if < 0), the children are stopped when it's exceeded
+	MaxRestarts int
+	// Window The time window to count restarts(forever if <= 0)
+	Window time.Duration
+}
+
+// DefaultSupervisorStrategy The default SupervisorStrategy: always restart the failed child only
+var DefaultSupervisorStrategy = SupervisorStrategy{
+	Type:        SupervisorOneForOne,
+	MaxRestarts: -1,
+}
+
+// ActorLifecycleType The type of ActorLifecycleMessage
+type ActorLifecycleType int
+
+const (
+	// ActorLifecycleChildFailed A child has panicked, Err & Directive are provided
+	ActorLifecycleChildFailed ActorLifecycleType = iota
+	// ActorLifecycleChildTerminated A child has been closed
+	ActorLifecycleChildTerminated
+	// ActorLifecycleWatchedTerminated A watched Actor has been closed(Child is the watched one)
+	ActorLifecycleWatchedTerminated
+)
+
+// ActorLifecycleMessage[T] The lifecycle message about a child(or a watched Actor) received by the parent(or the watcher)
+type ActorLifecycleMessage[T any] struct {
+	Type      ActorLifecycleType
+	Child     *ActorDef[T]
+	Err       error
+	Directive SupervisorDirective
+}
+
+// actorSystemMessage The internal messages handled before the normal ones
+type actorSystemMessage[T any] struct {
+	isRestart bool
+	lifecycle ActorLifecycleMessage[T]
 }
 
 // ActorDef[T] Actor model inspired by Erlang/Akka
 type ActorDef[T any] struct {
 	id       time.Time
 	isClosed bool
-	ch       chan T
+	mailbox  ActorMailbox[T]
 	effect   func(*ActorDef[T], T)
 
-	context map[string]interface{}
-
-	children map[time.Time]*ActorDef[T]
-	parent   *ActorDef[T]
+	behaviors      []func(*ActorDef[T], T)
+	currentMessage T
+	isReceiving    AtomBool
+	stash          *LinkedListQueue[T]
+	unstashed      *LinkedListQueue[T]
+
+	context        map[string]interface{}
+	initialContext map[string]interface{}
+
+	name          string
+	path          string
+	registry      *ActorRegistryDef[T]
+	children      map[time.Time]*ActorDef[T]
+	childrenNames map[string]*ActorDef[T]
+	parent        *ActorDef[T]
+	watchers      map[*ActorDef[T]]bool
+	isTerminated  bool
+	done          chan bool
+	timers        *cancellableGroup
+
+	lock               sync.RWMutex
+	supervisorStrategy *SupervisorStrategy
+	lifecycleEffect    func(*ActorDef[T], ActorLifecycleMessage[T])
+	restartTimes       []time.Time
+
+	systemLock     sync.Mutex
+	systemMessages []actorSystemMessage[T]
+	systemSignal   chan bool
 }
 
 var defaultActor *ActorDef[interface{}]
@@ -42,6 +175,11 @@ func (actorSelf *ActorDef[T]) NewByOptions(effect func(*ActorDef[T], T), ioCh ch
 	return ActorNewByOptionsGenerics(effect, ioCh, context)
 }
 
+// NewByMailbox New Actor by the ActorMailbox
+func (actorSelf *ActorDef[T]) NewByMailbox(effect func(*ActorDef[T], T), mailbox ActorMailbox[T], context map[string]interface{}) *ActorDef[T] {
+	return ActorNewByMailboxGenerics(effect, mailbox, context)
+}
+
 // ActorNewGenerics New Actor instance
 func ActorNewGenerics[T any](effect func(*ActorDef[T], T)) *ActorDef[T] {
 	return ActorNewByOptionsGenerics(effect, make(chan T), map[string]interface{}{})
@@ -49,48 +187,241 @@ func ActorNewGenerics[T any](effect func(*ActorDef[T], T)) *ActorDef[T] {
 
 // ActorNewByOptionsGenerics New Actor by its options
 func ActorNewByOptionsGenerics[T any](effect func(*ActorDef[T], T), ioCh chan T, context map[string]interface{}) *ActorDef[T] {
-	newOne := ActorDef[T]{
-		id:       time.Now(),
-		ch:       ioCh,
-		effect:   effect,
-		context:  context,
-		children: map[time.Time]*ActorDef[T]{},
+	return ActorNewByMailboxGenerics(effect, ActorChannelMailboxNewGenerics(ioCh), context)
+}
+
+// ActorNewByMailboxGenerics New Actor by the ActorMailbox
+func ActorNewByMailboxGenerics[T any](effect func(*ActorDef[T], T), mailbox ActorMailbox[T], context map[string]interface{}) *ActorDef[T] {
+	newOne, _ := actorNewGenerics(effect, mailbox, context, nil, nil, newActorName())
+	return newOne
+}
+
+// actorNewGenerics New Actor with the parent or the registry(for root ones), it starts after being registered
+func actorNewGenerics[T any](effect func(*ActorDef[T], T), mailbox ActorMailbox[T], context map[string]interface{}, parent *ActorDef[T], registry *ActorRegistryDef[T], name string) (*ActorDef[T], error) {
+	initialContext := make(map[string]interface{}, len(context))
+	for k, v := range context {
+		initialContext[k] = v
+	}
+	newOne := &ActorDef[T]{
+		id:             newActorID(),
+		mailbox:        mailbox,
+		effect:         effect,
+		behaviors:      []func(*ActorDef[T], T){effect},
+		stash:          NewLinkedListQueue[T](),
+		unstashed:      NewLinkedListQueue[T](),
+		context:        context,
+		initialContext: initialContext,
+		name:           name,
+		path:           "/" + name,
+		registry:       registry,
+		children:       map[time.Time]*ActorDef[T]{},
+		childrenNames:  map[string]*ActorDef[T]{},
+		watchers:       map[*ActorDef[T]]bool{},
+		done:           make(chan bool),
+		timers:         newCancellableGroup(),
+		parent:         parent,
+		systemSignal:   make(chan bool, 1),
+	}
+
+	if parent != nil {
+		newOne.path = parent.path + "/" + name
+		parent.lock.Lock()
+		newOne.registry = parent.registry
+		if parent.isClosed {
+			parent.lock.Unlock()
+			return nil, ErrQueueIsClosed
+		}
+		if parent.childrenNames[name] != nil {
+			parent.lock.Unlock()
+			return nil, ErrActorNameConflict
+		}
+		parent.children[newOne.id] = newOne
+		parent.childrenNames[name] = newOne
+		parent.lock.Unlock()
+	}
+	if newOne.registry != nil {
+		err := newOne.registry.register(newOne)
+		if err != nil {
+			if parent != nil {
+				parent.lock.Lock()
+				delete(parent.children, newOne.id)
+				delete(parent.childrenNames, name)
+				parent.lock.Unlock()
+			}
+			return nil, err
+		}
 	}
 
 	go newOne.run()
 
-	return &newOne
+	return newOne, nil
 }
 
-// Send Send a message to the Actor
-func (actorSelf *ActorDef[T]) Send(message T) {
-	if actorSelf.isClosed {
-		return
+// Send Send a message to the Actor(ErrQueueIsClosed if it's closed, ErrQueueIsFull if its mailbox is full)
+func (actorSelf *ActorDef[T]) Send(message T) error {
+	if actorSelf.IsClosed() {
+		return ErrQueueIsClosed
+	}
+
+	return actorSelf.mailbox.Send(message)
+}
+
+// SendAfter Send the message to the Actor after the delay, it's cancelled if the Actor is closed before that
+func (actorSelf *ActorDef[T]) SendAfter(message T, delay time.Duration) *CancellableDef {
+	if actorSelf.timers == nil {
+		return newCancelledCancellable()
+	}
+
+	return actorSelf.timers.schedule(delay, 0, func() bool {
+		return actorSelf.Send(message) == nil
+	})
+}
+
+// SendEvery Send the message to the Actor every interval(fixed delay) until it's cancelled or the Actor is closed
+func (actorSelf *ActorDef[T]) SendEvery(message T, interval time.Duration) *CancellableDef {
+	if actorSelf.timers == nil {
+		return newCancelledCancellable()
 	}
 
-	actorSelf.ch <- message
+	return actorSelf.timers.schedule(interval, interval, func() bool {
+		return actorSelf.Send(message) == nil
+	})
 }
 
 // Spawn Spawn a new Actor with parent(this actor)
 func (actorSelf *ActorDef[T]) Spawn(effect func(*ActorDef[T], T)) *ActorDef[T] {
-	newOne := actorSelf.New(effect)
-	if actorSelf.isClosed {
-		return newOne
+	newOne, err := actorSelf.spawnByMailbox(newActorName(), effect, ActorChannelMailboxNewGenerics(make(chan T)), map[string]interface{}{})
+	if err != nil {
+		// Closed: a new one without parent
+		return actorSelf.New(effect)
 	}
 
-	newOne.parent = actorSelf
-	actorSelf.children[newOne.id] = newOne
-
 	return newOne
 }
 
+// SpawnNamed Spawn a new Actor with parent(this actor) & the name(unique among its siblings, its path is "<parent path>/<name>")
+func (actorSelf *ActorDef[T]) SpawnNamed(name string, effect func(*ActorDef[T], T)) (*ActorDef[T], error) {
+	return actorSelf.SpawnByMailbox(name, effect, ActorChannelMailboxNewGenerics(make(chan T)), map[string]interface{}{})
+}
+
+// SpawnByMailbox Spawn a new Actor with parent(this actor), the name(unique among its siblings, its path is "<parent path>/<name>") & the ActorMailbox
+func (actorSelf *ActorDef[T]) SpawnByMailbox(name string, effect func(*ActorDef[T], T), mailbox ActorMailbox[T], context map[string]interface{}) (*ActorDef[T], error) {
+	if !isActorNameValid(name) {
+		return nil, ErrActorNameInvalid
+	}
+
+	return actorSelf.spawnByMailbox(name, effect, mailbox, context)
+}
+
+// spawnByMailbox Spawn a new Actor without checking the name(for the generated "$N" ones)
+func (actorSelf *ActorDef[T]) spawnByMailbox(name string, effect func(*ActorDef[T], T), mailbox ActorMailbox[T], context map[string]interface{}) (*ActorDef[T], error) {
+	if actorSelf.IsClosed() {
+		return nil, ErrQueueIsClosed
+	}
+
+	return actorNewGenerics(effect, mailbox, context, actorSelf, nil, name)
+}
+
+// SetSupervisorStrategy Set the SupervisorStrategy for its children(DefaultSupervisorStrategy by default)
+func (actorSelf *ActorDef[T]) SetSupervisorStrategy(strategy SupervisorStrategy) *ActorDef[T] {
+	actorSelf.lock.Lock()
+	actorSelf.supervisorStrategy = &strategy
+	actorSelf.lock.Unlock()
+
+	return actorSelf
+}
+
+// SetLifecycleEffect Set the effect receiving ActorLifecycleMessages about its children(in the goroutine of this Actor)
+func (actorSelf *ActorDef[T]) SetLifecycleEffect(effect func(*ActorDef[T], ActorLifecycleMessage[T])) *ActorDef[T] {
+	actorSelf.lock.Lock()
+	actorSelf.lifecycleEffect = effect
+	actorSelf.lock.Unlock()
+
+	return actorSelf
+}
+
 // GetChild Get a child Actor by ID
 func (actorSelf *ActorDef[T]) GetChild(id time.Time) *ActorDef[T] {
+	actorSelf.lock.RLock()
+	defer actorSelf.lock.RUnlock()
+
 	return actorSelf.children[id]
 }
 
+// GetChildByName Get a child Actor by its name
+func (actorSelf *ActorDef[T]) GetChildByName(name string) *ActorDef[T] {
+	actorSelf.lock.RLock()
+	defer actorSelf.lock.RUnlock()
+
+	return actorSelf.childrenNames[name]
+}
+
+// GetName Get its name
+func (actorSelf *ActorDef[T]) GetName() string {
+	return actorSelf.name
+}
+
+// GetPath Get its path(e.g. "/user/orders/worker-3")
+func (actorSelf *ActorDef[T]) GetPath() string {
+	return actorSelf.path
+}
+
+// Watch Let the watcher receive ActorLifecycleWatchedTerminated when this Actor is closed(immediately if it has been closed)
+func (actorSelf *ActorDef[T]) Watch(watcher *ActorDef[T]) {
+	actorSelf.lock.Lock()
+	if !actorSelf.isTerminated {
+		actorSelf.watchers[watcher] = true
+		actorSelf.lock.Unlock()
+		return
+	}
+	actorSelf.lock.Unlock()
+
+	watcher.sendSystemMessage(actorSystemMessage[T]{lifecycle: ActorLifecycleMessage[T]{
+		Type:  ActorLifecycleWatchedTerminated,
+		Child: actorSelf,
+	}})
+}
+
+// Unwatch Stop notifying the watcher
+func (actorSelf *ActorDef[T]) Unwatch(watcher *ActorDef[T]) {
+	actorSelf.lock.Lock()
+	defer actorSelf.lock.Unlock()
+
+	delete(actorSelf.watchers, watcher)
+}
+
+// Shutdown Close its descendants(children first) & itself, then wait for their termination(don't call it inside its own effect), returns ctx.Err() if ctx is done before that
+func (actorSelf *ActorDef[T]) Shutdown(ctx context.Context) error {
+	actorSelf.lock.RLock()
+	children := make([]*ActorDef[T], 0, len(actorSelf.childrenNames))
+	for _, child := range actorSelf.childrenNames {
+		children = append(children, child)
+	}
+	actorSelf.lock.RUnlock()
+
+	for _, child := range children {
+		if err := child.Shutdown(ctx); err != nil {
+			return err
+		}
+	}
+
+	actorSelf.Close()
+	if actorSelf.done == nil {
+		return nil
+	}
+	select {
+	case <-actorSelf.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // GetParent Get its parent Actor
 func (actorSelf *ActorDef[T]) GetParent() *ActorDef[T] {
+	actorSelf.lock.RLock()
+	defer actorSelf.lock.RUnlock()
+
 	return actorSelf.parent
 }
 
@@ -101,29 +432,278 @@ func (actorSelf *ActorDef[T]) GetID() time.Time {
 
 // Close Close the Actor
 func (actorSelf *ActorDef[T]) Close() {
+	actorSelf.lock.Lock()
+	defer actorSelf.lock.Unlock()
+	if actorSelf.isClosed {
+		return
+	}
+
 	actorSelf.isClosed = true
 
-	close(actorSelf.ch)
+	actorSelf.mailbox.Close()
 }
 
 // IsClosed Check is Closed
 func (actorSelf *ActorDef[T]) IsClosed() bool {
+	actorSelf.lock.RLock()
+	defer actorSelf.lock.RUnlock()
+
 	return actorSelf.isClosed
 }
 
 func (actorSelf *ActorDef[T]) run() {
-	for message := range actorSelf.ch {
-		actorSelf.effect(actorSelf, message)
+	defer actorSelf.terminated()
+
+	for {
+		// The unstashed messages go first
+		if message, err := actorSelf.unstashed.Poll(); err == nil {
+			actorSelf.receive(message)
+			continue
+		}
+
+		select {
+		case message, ok := <-actorSelf.mailbox.GetChannel():
+			if !ok {
+				return
+			}
+			actorSelf.receive(message)
+		case <-actorSelf.systemSignal:
+			actorSelf.receiveSystemMessages()
+		}
+	}
+}
+
+func (actorSelf *ActorDef[T]) receive(message T) {
+	actorSelf.isReceiving.Set(true)
+	defer actorSelf.isReceiving.Set(false)
+	defer func() {
+		if r := recover(); r != nil {
+			actorSelf.fail(NewPanicError(r))
+		}
+	}()
+
+	actorSelf.currentMessage = message
+	actorSelf.behaviors[len(actorSelf.behaviors)-1](actorSelf, message)
+}
+
+// Become Replace the current behavior by the effect(call it inside its effect)
+func (actorSelf *ActorDef[T]) Become(effect func(*ActorDef[T], T)) {
+	actorSelf.behaviors[len(actorSelf.behaviors)-1] = effect
+}
+
+// BecomeStacked Push the effect as the current behavior, the previous one comes back by Unbecome(call it inside its effect)
+func (actorSelf *ActorDef[T]) BecomeStacked(effect func(*ActorDef[T], T)) {
+	actorSelf.behaviors = append(actorSelf.behaviors, effect)
+}
+
+// Unbecome Pop the current behavior pushed by BecomeStacked(call it inside its effect)
+func (actorSelf *ActorDef[T]) Unbecome() {
+	if len(actorSelf.behaviors) > 1 {
+		actorSelf.behaviors = actorSelf.behaviors[:len(actorSelf.behaviors)-1]
 	}
 }
 
+// Stash Defer the current message until UnstashAll(call it inside its effect)
+func (actorSelf *ActorDef[T]) Stash() {
+	actorSelf.stash.Offer(actorSelf.currentMessage)
+}
+
+// UnstashAll Receive the stashed messages(in the stashed order) before the ones in the mailbox(call it inside its effect)
+func (actorSelf *ActorDef[T]) UnstashAll() {
+	for actorSelf.stash.Count() > 0 {
+		message, _ := actorSelf.stash.Pop()
+		actorSelf.unstashed.Unshift(message)
+	}
+}
+
+// sendSystemMessage Enqueue an internal message without blocking the sender
+func (actorSelf *ActorDef[T]) sendSystemMessage(message actorSystemMessage[T]) {
+	actorSelf.systemLock.Lock()
+	actorSelf.systemMessages = append(actorSelf.systemMessages, message)
+	actorSelf.systemLock.Unlock()
+
+	select {
+	case actorSelf.systemSignal <- true:
+	default:
+	}
+}
+
+func (actorSelf *ActorDef[T]) receiveSystemMessages() {
+	actorSelf.systemLock.Lock()
+	messages := actorSelf.systemMessages
+	actorSelf.systemMessages = nil
+	actorSelf.systemLock.Unlock()
+
+	for _, message := range messages {
+		if message.isRestart {
+			actorSelf.restart()
+			continue
+		}
+		actorSelf.supervise(message.lifecycle)
+	}
+}
+
+// restart Reset the context & the behavior to the initial ones, and unstash all messages
+func (actorSelf *ActorDef[T]) restart() {
+	actorSelf.behaviors = []func(*ActorDef[T], T){actorSelf.effect}
+	actorSelf.UnstashAll()
+	actorSelf.context = make(map[string]interface{}, len(actorSelf.initialContext))
+	for k, v := range actorSelf.initialContext {
+		actorSelf.context[k] = v
+	}
+}
+
+func (actorSelf *ActorDef[T]) getSupervisorStrategy() SupervisorStrategy {
+	actorSelf.lock.RLock()
+	defer actorSelf.lock.RUnlock()
+
+	if actorSelf.supervisorStrategy == nil {
+		return DefaultSupervisorStrategy
+	}
+	return *actorSelf.supervisorStrategy
+}
+
+// fail Decide the directive by the SupervisorStrategy of the parent & apply it to itself(in its goroutine)
+func (actorSelf *ActorDef[T]) fail(err error) {
+	parent := actorSelf.GetParent()
+	strategy := DefaultSupervisorStrategy
+	if parent != nil {
+		strategy = parent.getSupervisorStrategy()
+	}
+
+	now := time.Now()
+	if strategy.Window > 0 {
+		deadline := now.Add(-strategy.Window)
+		i := 0
+		for i < len(actorSelf.restartTimes) && actorSelf.restartTimes[i].Before(deadline) {
+			i++
+		}
+		actorSelf.restartTimes = actorSelf.restartTimes[i:]
+	}
+	directive := SupervisorRestart
+	if strategy.MaxRestarts >= 0 && len(actorSelf.restartTimes) >= strategy.MaxRestarts {
+		directive = SupervisorStop
+	}
+
+	if directive == SupervisorRestart {
+		actorSelf.restartTimes = append(actorSelf.restartTimes, now)
+		actorSelf.restart()
+	} else {
+		actorSelf.Close()
+	}
+
+	// The siblings are handled by the parent
+	if parent != nil {
+		parent.sendSystemMessage(actorSystemMessage[T]{lifecycle: ActorLifecycleMessage[T]{
+			Type:      ActorLifecycleChildFailed,
+			Child:     actorSelf,
+			Err:       err,
+			Directive: directive,
+		}})
+	}
+}
+
+// terminated Unregister it & notify the parent & the watchers when it's closed
+func (actorSelf *ActorDef[T]) terminated() {
+	actorSelf.lock.Lock()
+	actorSelf.isTerminated = true
+	watchers := actorSelf.watchers
+	actorSelf.watchers = map[*ActorDef[T]]bool{}
+	actorSelf.lock.Unlock()
+	actorSelf.timers.close()
+
+	if actorSelf.registry != nil {
+		actorSelf.registry.unregister(actorSelf)
+	}
+	parent := actorSelf.GetParent()
+	if parent != nil {
+		parent.lock.Lock()
+		if parent.childrenNames[actorSelf.name] == actorSelf {
+			delete(parent.childrenNames, actorSelf.name)
+		}
+		parent.lock.Unlock()
+
+		parent.sendSystemMessage(actorSystemMessage[T]{lifecycle: ActorLifecycleMessage[T]{
+			Type:  ActorLifecycleChildTerminated,
+			Child: actorSelf,
+		}})
+	}
+	for watcher := range watchers {
+		watcher.sendSystemMessage(actorSystemMessage[T]{lifecycle: ActorLifecycleMessage[T]{
+			Type:  ActorLifecycleWatchedTerminated,
+			Child: actorSelf,
+		}})
+	}
+
+	close(actorSelf.done)
+}
+
+// supervise Handle the lifecycle of the children(in the goroutine of the parent)
+func (actorSelf *ActorDef[T]) supervise(message ActorLifecycleMessage[T]) {
+	switch message.Type {
+	case ActorLifecycleChildFailed:
+		strategy := actorSelf.getSupervisorStrategy()
+		for _, sibling := range actorSelf.getAffectedSiblings(strategy.Type, message.Child) {
+			if message.Directive == SupervisorRestart {
+				sibling.sendSystemMessage(actorSystemMessage[T]{isRestart: true})
+			} else {
+				sibling.Close()
+			}
+		}
+	case ActorLifecycleChildTerminated:
+		actorSelf.lock.Lock()
+		if actorSelf.children[message.Child.id] == message.Child {
+			delete(actorSelf.children, message.Child.id)
+		}
+		actorSelf.lock.Unlock()
+	}
+
+	actorSelf.lock.RLock()
+	lifecycleEffect := actorSelf.lifecycleEffect
+	actorSelf.lock.RUnlock()
+	if lifecycleEffect != nil {
+		lifecycleEffect(actorSelf, message)
+	}
+}
+
+// getAffectedSiblings Get the other children affected by the failed one(in the spawned order)
+func (actorSelf *ActorDef[T]) getAffectedSiblings(strategyType SupervisorStrategyType, failed *ActorDef[T]) []*ActorDef[T] {
+	if strategyType == SupervisorOneForOne {
+		return nil
+	}
+
+	actorSelf.lock.RLock()
+	children := make([]*ActorDef[T], 0, len(actorSelf.children))
+	for _, child := range actorSelf.children {
+		children = append(children, child)
+	}
+	actorSelf.lock.RUnlock()
+	sort.Slice(children, func(i, j int) bool {
+		return children[i].id.Before(children[j].id)
+	})
+
+	var siblings []*ActorDef[T]
+	for _, child := range children {
+		if child == failed {
+			continue
+		}
+		if strategyType == SupervisorRestForOne && child.id.Before(failed.id) {
+			continue
+		}
+		siblings = append(siblings, child)
+	}
+	return siblings
+}
+
 // Actor Actor utils instance
 var Actor ActorDef[interface{}]
 
 // AskDef[T, R] Ask inspired by Erlang/Akka
 type AskDef[T any, R any] struct {
-	id time.Time
-	ch chan R
+	id       time.Time
+	ch       chan R
+	done     chan bool
+	doneOnce sync.Once
 
 	Message T
 }
@@ -146,8 +726,9 @@ func AskNewGenerics[T any, R any](message T) *AskDef[T, R] {
 // AskNewByOptionsGenerics New Ask by its options
 func AskNewByOptionsGenerics[T any, R any](message T, ioCh chan R) *AskDef[T, R] {
 	newOne := AskDef[T, R]{
-		id: time.Now(),
-		ch: ioCh,
+		id:   time.Now(),
+		ch:   ioCh,
+		done: make(chan bool),
 
 		Message: message,
 	}
@@ -155,27 +736,85 @@ func AskNewByOptionsGenerics[T any, R any](message T, ioCh chan R) *AskDef[T, R]
 	return &newOne
 }
 
-// AskOnce Sender Ask
+// ActorAskGenerics Ask the Actor receiving AskDefs directly, returns ctx.Err() if ctx is done before the reply
+func ActorAskGenerics[T any, R any](ctx context.Context, target ActorHandle[*AskDef[T, R]], message T) (R, error) {
+	return ActorAskByGenerics(ctx, target, message, func(ask *AskDef[T, R]) *AskDef[T, R] {
+		return ask
+	})
+}
+
+// ActorAskByGenerics Ask the Actor of any message type M(wrap the AskDef into M), returns ctx.Err() if ctx is done before the reply
+func ActorAskByGenerics[T any, R any, M any](ctx context.Context, target ActorHandle[M], message T, wrap func(*AskDef[T, R]) M) (R, error) {
+	ask := AskNewGenerics[T, R](message)
+	return ask.askContext(ctx, func() error {
+		return target.Send(wrap(ask))
+	})
+}
+
+// ActorAskFutureGenerics Ask the Actor receiving AskDefs directly & get the Future of the reply(rejected by ctx.Err() if ctx is done before the reply)
+func ActorAskFutureGenerics[T any, R any](ctx context.Context, target ActorHandle[*AskDef[T, R]], message T) *FutureDef[R] {
+	return FutureNewGenerics(nil, func() (R, error) {
+		return ActorAskGenerics(ctx, target, message)
+	})
+}
+
+// AskContext Sender Ask, returns ctx.Err() if ctx is done before the reply
+func (askSelf *AskDef[T, R]) AskContext(ctx context.Context, target ActorHandle[interface{}]) (R, error) {
+	return askSelf.askContext(ctx, func() error {
+		return target.Send(askSelf)
+	})
+}
+
+func (askSelf *AskDef[T, R]) askContext(ctx context.Context, send func() error) (R, error) {
+	// Late replies are discarded after returning
+	defer askSelf.discardReplies()
+
+	var result R
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
+	if err := send(); err != nil {
+		return result, err
+	}
+
+	select {
+	case result = <-askSelf.ch:
+		return result, nil
+	case <-ctx.Done():
+		return result, ctx.Err()
+	}
+}
+
+func (askSelf *AskDef[T, R]) discardReplies() {
+	askSelf.doneOnce.Do(func() {
+		close(askSelf.done)
+	})
+}
+
+// AskFuture Sender Ask & get the Future of the reply(rejected by ctx.Err() if ctx is done before the reply)
+func (askSelf *AskDef[T, R]) AskFuture(ctx context.Context, target ActorHandle[interface{}]) *FutureDef[R] {
+	return FutureNewGenerics(nil, func() (R, error) {
+		return askSelf.AskContext(ctx, target)
+	})
+}
+
+// AskOnce Sender Ask(the zero value is returned if the target is closed)
 func (askSelf *AskDef[T, R]) AskOnce(target ActorHandle[interface{}]) R {
-	ch := askSelf.AskChannel(target)
-	defer close(ch)
-	// var err error
+	result, _ := askSelf.AskContext(context.Background(), target)
 
-	return <-ch
+	return result
 }
 
 // AskOnceWithTimeout Sender Ask with timeout
 func (askSelf *AskDef[T, R]) AskOnceWithTimeout(target ActorHandle[interface{}], timeout time.Duration) (R, error) {
-	ch := askSelf.AskChannel(target)
-	defer close(ch)
-	var result R
-	select {
-	case result = <-ch:
-	case <-time.After(timeout):
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	result, err := askSelf.AskContext(ctx, target)
+	if err == context.DeadlineExceeded {
 		return result, ErrActorAskTimeout
 	}
-
-	return result, nil
+	return result, err
 }
 
 // AskChannel Sender Ask
@@ -185,9 +824,12 @@ func (askSelf *AskDef[T, R]) AskChannel(target ActorHandle[interface{}]) chan R
 	return askSelf.ch
 }
 
-// Reply Receiver Reply
+// Reply Receiver Reply(discarded if the sender has stopped waiting)
 func (askSelf *AskDef[T, R]) Reply(response R) {
-	askSelf.ch <- response
+	select {
+	case askSelf.ch <- response:
+	case <-askSelf.done:
+	}
 }
 
 // Ask Ask utils instance
@@ -0,0 +1,310 @@
+package fpgo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// ErrActorJournalSequenceConflict The sequenceNr to append isn't the next one of the journal(another writer of the same persistenceID?)
+var ErrActorJournalSequenceConflict = fmt.Errorf("ErrActorJournalSequenceConflict")
+
+// ActorSnapshot[S] The snapshot of the state after the event of SequenceNr
+type ActorSnapshot[S any] struct {
+	SequenceNr int `json:"sequenceNr"`
+	State      S   `json:"state"`
+}
+
+// ActorJournal[E, S] The append-only journal of the events(E) & the snapshots of the states(S) of persistent Actors
+type ActorJournal[E any, S any] interface {
+	// Append Append the event, sequenceNr must be the last one + 1(starting from 1)
+	Append(persistenceID string, sequenceNr int, event E) error
+	// Replay Replay the events with sequenceNr >= fromSequenceNr in order
+	Replay(persistenceID string, fromSequenceNr int, replay func(sequenceNr int, event E)) error
+	// SaveSnapshot Save the snapshot(replacing the previous one, unless the previous one has a greater SequenceNr)
+	SaveSnapshot(persistenceID string, snapshot ActorSnapshot[S]) error
+	// LoadSnapshot Load the latest snapshot(nil if there is none)
+	LoadSnapshot(persistenceID string) (*ActorSnapshot[S], error)
+}
+
+// MemoryJournal
+
+type actorJournalEvent[E any] struct {
+	SequenceNr int `json:"sequenceNr"`
+	Event      E   `json:"event"`
+}
+
+// ActorMemoryJournalDef[E, S] The in-memory ActorJournal(for tests & the volatile usages)
+type ActorMemoryJournalDef[E any, S any] struct {
+	lock      sync.RWMutex
+	events    map[string][]actorJournalEvent[E]
+	snapshots map[string]ActorSnapshot[S]
+}
+
+// ActorMemoryJournalNewGenerics New an in-memory ActorJournal
+func ActorMemoryJournalNewGenerics[E any, S any]() *ActorMemoryJournalDef[E, S] {
+	return &ActorMemoryJournalDef[E, S]{
+		events:    map[string][]actorJournalEvent[E]{},
+		snapshots: map[string]ActorSnapshot[S]{},
+	}
+}
+
+// Append Append the event, sequenceNr must be the last one + 1(starting from 1)
+func (journalSelf *ActorMemoryJournalDef[E, S]) Append(persistenceID string, sequenceNr int, event E) error {
+	journalSelf.lock.Lock()
+	defer journalSelf.lock.Unlock()
+
+	events := journalSelf.events[persistenceID]
+	lastSequenceNr := 0
+	if len(events) > 0 {
+		lastSequenceNr = events[len(events)-1].SequenceNr
+	}
+	if sequenceNr != lastSequenceNr+1 {
+		return ErrActorJournalSequenceConflict
+	}
+	journalSelf.events[persistenceID] = append(events, actorJournalEvent[E]{SequenceNr: sequenceNr, Event: event})
+	return nil
+}
+
+// Replay Replay the events with sequenceNr >= fromSequenceNr in order
+func (journalSelf *ActorMemoryJournalDef[E, S]) Replay(persistenceID string, fromSequenceNr int, replay func(sequenceNr int, event E)) error {
+	journalSelf.lock.RLock()
+	events := journalSelf.events[persistenceID]
+	journalSelf.lock.RUnlock()
+
+	// Appending never modifies the existing items
+	for _, event := range events {
+		if event.SequenceNr >= fromSequenceNr {
+			replay(event.SequenceNr, event.Event)
+		}
+	}
+	return nil
+}
+
+// SaveSnapshot Save the snapshot(replacing the previous one, an older one is ignored)
+func (journalSelf *ActorMemoryJournalDef[E, S]) SaveSnapshot(persistenceID string, snapshot ActorSnapshot[S]) error {
+	journalSelf.lock.Lock()
+	defer journalSelf.lock.Unlock()
+
+	// Concurrent saves may arrive out of order
+	if previous, ok := journalSelf.snapshots[persistenceID]; ok && previous.SequenceNr > snapshot.SequenceNr {
+		return nil
+	}
+	journalSelf.snapshots[persistenceID] = snapshot
+	return nil
+}
+
+// LoadSnapshot Load the latest snapshot(nil if there is none)
+func (journalSelf *ActorMemoryJournalDef[E, S]) LoadSnapshot(persistenceID string) (*ActorSnapshot[S], error) {
+	journalSelf.lock.RLock()
+	defer journalSelf.lock.RUnlock()
+
+	snapshot, ok := journalSelf.snapshots[persistenceID]
+	if !ok {
+		return nil, nil
+	}
+	return &snapshot, nil
+}
+
+// FileJournal
+
+// ActorFileJournalDef[E, S] The file-backed append-only ActorJournal(JSON encoded, one events file & one snapshot file per persistenceID in the dir)
+type ActorFileJournalDef[E any, S any] struct {
+	lock            sync.Mutex
+	dir             string
+	lastSequenceNrs map[string]int
+}
+
+// ActorFileJournalNewGenerics New a file-backed ActorJournal in the dir(created if not existing)
+func ActorFileJournalNewGenerics[E any, S any](dir string) (*ActorFileJournalDef[E, S], error) {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ActorFileJournalDef[E, S]{
+		dir:             dir,
+		lastSequenceNrs: map[string]int{},
+	}, nil
+}
+
+func (journalSelf *ActorFileJournalDef[E, S]) getEventsPath(persistenceID string) string {
+	return filepath.Join(journalSelf.dir, url.PathEscape(persistenceID)+".journal")
+}
+
+func (journalSelf *ActorFileJournalDef[E, S]) getSnapshotPath(persistenceID string) string {
+	return filepath.Join(journalSelf.dir, url.PathEscape(persistenceID)+".snapshot")
+}
+
+// readEvents Read the events file, returns the offset after the last complete event if the tail is torn(ignored), or -1
+func (journalSelf *ActorFileJournalDef[E, S]) readEvents(persistenceID string, replay func(event actorJournalEvent[E])) (int64, error) {
+	file, err := os.Open(journalSelf.getEventsPath(persistenceID))
+	if errors.Is(err, os.ErrNotExist) {
+		return -1, nil
+	}
+	if err != nil {
+		return -1, err
+	}
+	defer file.Close()
+
+	decoder := json.NewDecoder(file)
+	offset := int64(0)
+	for {
+		var event actorJournalEvent[E]
+		err = decoder.Decode(&event)
+		if err == io.EOF {
+			return -1, nil
+		}
+		if err == io.ErrUnexpectedEOF {
+			return offset, nil
+		}
+		if err != nil {
+			return -1, err
+		}
+		offset = decoder.InputOffset()
+		replay(event)
+	}
+}
+
+// getLastSequenceNr Get the last sequenceNr(loaded from the file at the first time, truncating the torn tail)
+func (journalSelf *ActorFileJournalDef[E, S]) getLastSequenceNr(persistenceID string) (int, error) {
+	lastSequenceNr, ok := journalSelf.lastSequenceNrs[persistenceID]
+	if ok {
+		return lastSequenceNr, nil
+	}
+
+	offset, err := journalSelf.readEvents(persistenceID, func(event actorJournalEvent[E]) {
+		lastSequenceNr = event.SequenceNr
+	})
+	if err != nil {
+		return 0, err
+	}
+	if offset >= 0 {
+		err = os.Truncate(journalSelf.getEventsPath(persistenceID), offset)
+		if err != nil {
+			return 0, err
+		}
+	}
+	journalSelf.lastSequenceNrs[persistenceID] = lastSequenceNr
+	return lastSequenceNr, nil
+}
+
+// Append Append the event, sequenceNr must be the last one + 1(starting from 1)
+func (journalSelf *ActorFileJournalDef[E, S]) Append(persistenceID string, sequenceNr int, event E) error {
+	journalSelf.lock.Lock()
+	defer journalSelf.lock.Unlock()
+
+	lastSequenceNr, err := journalSelf.getLastSequenceNr(persistenceID)
+	if err != nil {
+		return err
+	}
+	if sequenceNr != lastSequenceNr+1 {
+		return ErrActorJournalSequenceConflict
+	}
+
+	data, err := json.Marshal(actorJournalEvent[E]{SequenceNr: sequenceNr, Event: event})
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(journalSelf.getEventsPath(persistenceID), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = file.Write(append(data, '\n'))
+	if err == nil {
+		err = file.Sync()
+	}
+	if err != nil {
+		// Reload it next time
+		delete(journalSelf.lastSequenceNrs, persistenceID)
+		return err
+	}
+
+	journalSelf.lastSequenceNrs[persistenceID] = sequenceNr
+	return nil
+}
+
+// Replay Replay the events with sequenceNr >= fromSequenceNr in order
+func (journalSelf *ActorFileJournalDef[E, S]) Replay(persistenceID string, fromSequenceNr int, replay func(sequenceNr int, event E)) error {
+	journalSelf.lock.Lock()
+	defer journalSelf.lock.Unlock()
+
+	_, err := journalSelf.readEvents(persistenceID, func(event actorJournalEvent[E]) {
+		if event.SequenceNr >= fromSequenceNr {
+			replay(event.SequenceNr, event.Event)
+		}
+	})
+	return err
+}
+
+// SaveSnapshot Save the snapshot(replacing the previous one atomically, an older one is ignored)
+func (journalSelf *ActorFileJournalDef[E, S]) SaveSnapshot(persistenceID string, snapshot ActorSnapshot[S]) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	journalSelf.lock.Lock()
+	defer journalSelf.lock.Unlock()
+
+	// Concurrent saves may arrive out of order
+	previousData, err := os.ReadFile(journalSelf.getSnapshotPath(persistenceID))
+	if err == nil {
+		var previous struct {
+			SequenceNr int `json:"sequenceNr"`
+		}
+		if json.Unmarshal(previousData, &previous) == nil && previous.SequenceNr > snapshot.SequenceNr {
+			return nil
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	// A unique temp file: the writers of the other journals of the same dir don't clobber it
+	file, err := os.CreateTemp(journalSelf.dir, url.PathEscape(persistenceID)+".snapshot.*.tmp")
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(data)
+	if err == nil {
+		err = file.Sync()
+	}
+	closeErr := file.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), journalSelf.getSnapshotPath(persistenceID))
+	}
+	if err != nil {
+		os.Remove(file.Name())
+		return err
+	}
+	return nil
+}
+
+// LoadSnapshot Load the latest snapshot(nil if there is none)
+func (journalSelf *ActorFileJournalDef[E, S]) LoadSnapshot(persistenceID string) (*ActorSnapshot[S], error) {
+	journalSelf.lock.Lock()
+	data, err := os.ReadFile(journalSelf.getSnapshotPath(persistenceID))
+	journalSelf.lock.Unlock()
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshot ActorSnapshot[S]
+	err = json.Unmarshal(data, &snapshot)
+	if err != nil {
+		return nil, err
+	}
+	return &snapshot, nil
+}
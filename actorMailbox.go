@@ -0,0 +1,250 @@
+package fpgo
+
+import (
+	"math"
+	"sync"
+)
+
+// ActorMailbox[T] The mailbox of an Actor
+type ActorMailbox[T any] interface {
+	// Send Enqueue the message(ErrQueueIsClosed if it's closed, ErrQueueIsFull if it's full)
+	Send(message T) error
+	// GetChannel Get the channel delivering messages to the Actor(closed after the mailbox is closed & drained)
+	GetChannel() <-chan T
+	// Count Count the pending messages
+	Count() int
+	// Close Close the mailbox(idempotent)
+	Close()
+	// IsClosed Is the mailbox closed
+	IsClosed() bool
+}
+
+// ChannelMailbox
+
+type actorChannelMailbox[T any] struct {
+	lock       sync.RWMutex
+	closeOnce  sync.Once
+	isClosed   AtomBool
+	isBlocking bool
+
+	ch   chan T
+	done chan bool
+}
+
+// ActorChannelMailboxNewGenerics New a mailbox by the chan(Send blocks until the message is taken into the chan)
+func ActorChannelMailboxNewGenerics[T any](ch chan T) ActorMailbox[T] {
+	return &actorChannelMailbox[T]{
+		isBlocking: true,
+		ch:         ch,
+		done:       make(chan bool),
+	}
+}
+
+// ActorBoundedMailboxNewGenerics New a mailbox by a ChannelQueue with the capacity(Send returns ErrQueueIsFull if it's full)
+func ActorBoundedMailboxNewGenerics[T any](capacity int) ActorMailbox[T] {
+	return &actorChannelMailbox[T]{
+		ch:   NewChannelQueue[T](capacity),
+		done: make(chan bool),
+	}
+}
+
+func (mailboxSelf *actorChannelMailbox[T]) Send(message T) error {
+	mailboxSelf.lock.RLock()
+	defer mailboxSelf.lock.RUnlock()
+	if mailboxSelf.isClosed.Get() {
+		return ErrQueueIsClosed
+	}
+
+	if !mailboxSelf.isBlocking {
+		return ChannelQueue[T](mailboxSelf.ch).Offer(message)
+	}
+	select {
+	case mailboxSelf.ch <- message:
+		return nil
+	case <-mailboxSelf.done:
+		return ErrQueueIsClosed
+	}
+}
+
+func (mailboxSelf *actorChannelMailbox[T]) GetChannel() <-chan T {
+	return mailboxSelf.ch
+}
+
+func (mailboxSelf *actorChannelMailbox[T]) Count() int {
+	return len(mailboxSelf.ch)
+}
+
+func (mailboxSelf *actorChannelMailbox[T]) Close() {
+	mailboxSelf.closeOnce.Do(func() {
+		mailboxSelf.isClosed.Set(true)
+		// Release the blocking senders before closing the chan
+		close(mailboxSelf.done)
+
+		mailboxSelf.lock.Lock()
+		defer mailboxSelf.lock.Unlock()
+		close(mailboxSelf.ch)
+	})
+}
+
+func (mailboxSelf *actorChannelMailbox[T]) IsClosed() bool {
+	return mailboxSelf.isClosed.Get()
+}
+
+// BufferedMailbox
+
+type actorBufferedMailbox[T any] struct {
+	closeOnce sync.Once
+	queue     *BufferedChannelQueue[T]
+}
+
+// ActorBufferedMailboxNewGenerics New a mailbox by the BufferedChannelQueue(Send returns ErrQueueIsFull if it's full)
+func ActorBufferedMailboxNewGenerics[T any](queue *BufferedChannelQueue[T]) ActorMailbox[T] {
+	return &actorBufferedMailbox[T]{
+		queue: queue,
+	}
+}
+
+// ActorUnboundedMailboxNewGenerics New an unbounded mailbox by a BufferedChannelQueue
+func ActorUnboundedMailboxNewGenerics[T any]() ActorMailbox[T] {
+	return ActorBufferedMailboxNewGenerics(NewBufferedChannelQueue[T](16, math.MaxInt, 16))
+}
+
+func (mailboxSelf *actorBufferedMailbox[T]) Send(message T) error {
+	return mailboxSelf.queue.Offer(message)
+}
+
+func (mailboxSelf *actorBufferedMailbox[T]) GetChannel() <-chan T {
+	return mailboxSelf.queue.GetChannel()
+}
+
+func (mailboxSelf *actorBufferedMailbox[T]) Count() int {
+	return mailboxSelf.queue.Count()
+}
+
+func (mailboxSelf *actorBufferedMailbox[T]) Close() {
+	mailboxSelf.closeOnce.Do(mailboxSelf.queue.Close)
+}
+
+func (mailboxSelf *actorBufferedMailbox[T]) IsClosed() bool {
+	return mailboxSelf.queue.IsClosed()
+}
+
+// PriorityMailbox
+
+type actorPriorityMailbox[T any] struct {
+	lock     sync.Mutex
+	isClosed bool
+	queue    *PriorityQueue[T]
+
+	signal chan bool
+	ch     chan T
+}
+
+// ActorPriorityMailboxNewGenerics New an unbounded mailbox by a PriorityQueue(less(a, b) means a is received before b)
+func ActorPriorityMailboxNewGenerics[T any](less func(a, b T) bool) ActorMailbox[T] {
+	newOne := &actorPriorityMailbox[T]{
+		queue:  NewPriorityQueue(less),
+		signal: make(chan bool, 1),
+		ch:     make(chan T),
+	}
+	go newOne.run()
+
+	return newOne
+}
+
+// run Deliver the message with the highest priority whenever the Actor is ready
+func (mailboxSelf *actorPriorityMailbox[T]) run() {
+	for {
+		mailboxSelf.lock.Lock()
+		item, err := mailboxSelf.queue.pollItem()
+		isClosed := mailboxSelf.isClosed
+		mailboxSelf.lock.Unlock()
+
+		if err != nil {
+			if isClosed {
+				close(mailboxSelf.ch)
+				return
+			}
+			<-mailboxSelf.signal
+			continue
+		}
+
+		select {
+		case mailboxSelf.ch <- item.val:
+		case <-mailboxSelf.signal:
+			// Something new: put it back & check the priority again
+			mailboxSelf.lock.Lock()
+			mailboxSelf.queue.offerItem(item)
+			mailboxSelf.lock.Unlock()
+		}
+	}
+}
+
+func (mailboxSelf *actorPriorityMailbox[T]) notify() {
+	select {
+	case mailboxSelf.signal <- true:
+	default:
+	}
+}
+
+func (mailboxSelf *actorPriorityMailbox[T]) Send(message T) error {
+	mailboxSelf.lock.Lock()
+	defer mailboxSelf.lock.Unlock()
+	if mailboxSelf.isClosed {
+		return ErrQueueIsClosed
+	}
+
+	mailboxSelf.queue.Offer(message)
+	mailboxSelf.notify()
+	return nil
+}
+
+func (mailboxSelf *actorPriorityMailbox[T]) GetChannel() <-chan T {
+	return mailboxSelf.ch
+}
+
+func (mailboxSelf *actorPriorityMailbox[T]) Count() int {
+	mailboxSelf.lock.Lock()
+	defer mailboxSelf.lock.Unlock()
+
+	return mailboxSelf.queue.Count()
+}
+
+func (mailboxSelf *actorPriorityMailbox[T]) Close() {
+	mailboxSelf.lock.Lock()
+	defer mailboxSelf.lock.Unlock()
+
+	mailboxSelf.isClosed = true
+	mailboxSelf.notify()
+}
+
+func (mailboxSelf *actorPriorityMailbox[T]) IsClosed() bool {
+	mailboxSelf.lock.Lock()
+	defer mailboxSelf.lock.Unlock()
+
+	return mailboxSelf.isClosed
+}
+
+// DeadLetterMailbox
+
+type actorDeadLetterMailbox[T any] struct {
+	ActorMailbox[T]
+
+	deadLetter func(message T)
+}
+
+// ActorDeadLetterMailboxNewGenerics New a mailbox passing the overflowed messages of the mailbox to deadLetter(Send still returns ErrQueueIsFull)
+func ActorDeadLetterMailboxNewGenerics[T any](mailbox ActorMailbox[T], deadLetter func(message T)) ActorMailbox[T] {
+	return &actorDeadLetterMailbox[T]{
+		ActorMailbox: mailbox,
+		deadLetter:   deadLetter,
+	}
+}
+
+func (mailboxSelf *actorDeadLetterMailbox[T]) Send(message T) error {
+	err := mailboxSelf.ActorMailbox.Send(message)
+	if err == ErrQueueIsFull {
+		mailboxSelf.deadLetter(message)
+	}
+	return err
+}
@@ -0,0 +1,118 @@
+package fpgo
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActorMailboxSendAfterClose(t *testing.T) {
+	for _, mailbox := range []ActorMailbox[interface{}]{
+		ActorChannelMailboxNewGenerics(make(chan interface{})),
+		ActorBoundedMailboxNewGenerics[interface{}](1),
+		ActorUnboundedMailboxNewGenerics[interface{}](),
+		ActorPriorityMailboxNewGenerics(func(a, b interface{}) bool { return false }),
+	} {
+		actor := Actor.NewByMailbox(func(self *ActorDef[interface{}], input interface{}) {}, mailbox, map[string]interface{}{})
+		assert.NoError(t, actor.Send(1))
+		actor.Close()
+		actor.Close()
+		assert.Equal(t, ErrQueueIsClosed, actor.Send(2))
+		assert.True(t, mailbox.IsClosed())
+	}
+
+	// Blocking senders are released by Close
+	actor := Actor.New(func(self *ActorDef[interface{}], input interface{}) {
+		time.Sleep(50 * time.Millisecond)
+	})
+	actor.Send(1)
+	var err error
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		err = actor.Send(2)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	actor.Close()
+	wg.Wait()
+	assert.Equal(t, ErrQueueIsClosed, err)
+}
+
+func TestActorBoundedAndDeadLetterMailbox(t *testing.T) {
+	var lock sync.Mutex
+	deadLetters := []int{}
+	received := make(chan int, 10)
+	block := make(chan bool)
+	mailbox := ActorDeadLetterMailboxNewGenerics(ActorBoundedMailboxNewGenerics[int](2), func(message int) {
+		lock.Lock()
+		deadLetters = append(deadLetters, message)
+		lock.Unlock()
+	})
+	actor := ActorNewByMailboxGenerics(func(self *ActorDef[int], input int) {
+		<-block
+		received <- input
+	}, mailbox, map[string]interface{}{})
+
+	assert.NoError(t, actor.Send(1))
+	// Wait for the Actor taking 1
+	assert.Eventually(t, func() bool {
+		return mailbox.Count() == 0
+	}, time.Second, time.Millisecond)
+	assert.NoError(t, actor.Send(2))
+	assert.NoError(t, actor.Send(3))
+	assert.Equal(t, 2, mailbox.Count())
+	assert.Equal(t, ErrQueueIsFull, actor.Send(4))
+	close(block)
+	assert.Equal(t, 1, <-received)
+	assert.Equal(t, 2, <-received)
+	assert.Equal(t, 3, <-received)
+	lock.Lock()
+	assert.Equal(t, []int{4}, deadLetters)
+	lock.Unlock()
+}
+
+func TestActorUnboundedAndPriorityMailbox(t *testing.T) {
+	// Unbounded: senders don't wait for the busy Actor
+	received := make(chan int, 100)
+	block := make(chan bool)
+	actor := ActorNewByMailboxGenerics(func(self *ActorDef[int], input int) {
+		<-block
+		received <- input
+	}, ActorUnboundedMailboxNewGenerics[int](), map[string]interface{}{})
+	for i := 0; i < 100; i++ {
+		assert.NoError(t, actor.Send(i))
+	}
+	close(block)
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, i, <-received)
+	}
+
+	// Priority: the smaller the earlier, FIFO for the same priority
+	type message struct {
+		priority int
+		value    string
+	}
+	receivedPriority := make(chan string, 10)
+	block = make(chan bool)
+	actorPriority := ActorNewByMailboxGenerics(func(self *ActorDef[message], input message) {
+		<-block
+		receivedPriority <- input.value
+	}, ActorPriorityMailboxNewGenerics(func(a, b message) bool {
+		return a.priority < b.priority
+	}), map[string]interface{}{})
+	actorPriority.Send(message{priority: 9, value: "first"})
+	time.Sleep(10 * time.Millisecond)
+	actorPriority.Send(message{priority: 5, value: "a"})
+	actorPriority.Send(message{priority: 1, value: "b"})
+	actorPriority.Send(message{priority: 5, value: "c"})
+	actorPriority.Send(message{priority: 0, value: "d"})
+	close(block)
+	actual := []string{}
+	for i := 0; i < 5; i++ {
+		actual = append(actual, <-receivedPriority)
+	}
+	assert.Equal(t, []string{"first", "d", "b", "a", "c"}, actual)
+}
@@ -0,0 +1,158 @@
+package fpgo
+
+import (
+	"log"
+	"sync"
+)
+
+// PersistentActorDef[T, E, S] Event-sourced Actor inspired by Akka Persistence: commands(T) persist events(E) updating the state(S)
+type PersistentActorDef[T any, E any, S any] struct {
+	*ActorDef[T]
+
+	persistenceID string
+	journal       ActorJournal[E, S]
+	applyEvent    func(state S, event E) S
+
+	lock                   sync.RWMutex
+	state                  S
+	sequenceNr             int
+	snapshotEvery          int
+	lastSnapshotSequenceNr int
+	snapshotErrorHandler   func(error)
+}
+
+func defaultPersistentActorSnapshotErrorHandler(persistenceID string) func(error) {
+	return func(err error) {
+		log.Printf("persistent actor %s: snapshot failed: %v\n", persistenceID, err)
+	}
+}
+
+// PersistentActorNewGenerics New a PersistentActor recovered from the journal(the latest snapshot & the later events), applyEvent should return a new state instead of mutating it
+func PersistentActorNewGenerics[T any, E any, S any](persistenceID string, journal ActorJournal[E, S], initial S, applyEvent func(state S, event E) S, command func(self *PersistentActorDef[T, E, S], message T)) (*PersistentActorDef[T, E, S], error) {
+	return PersistentActorNewByMailboxGenerics(persistenceID, journal, initial, applyEvent, command, ActorChannelMailboxNewGenerics(make(chan T)))
+}
+
+// PersistentActorNewByMailboxGenerics New a PersistentActor by the ActorMailbox recovered from the journal(the latest snapshot & the later events)
+func PersistentActorNewByMailboxGenerics[T any, E any, S any](persistenceID string, journal ActorJournal[E, S], initial S, applyEvent func(state S, event E) S, command func(self *PersistentActorDef[T, E, S], message T), mailbox ActorMailbox[T]) (*PersistentActorDef[T, E, S], error) {
+	newOne := &PersistentActorDef[T, E, S]{
+		persistenceID: persistenceID,
+		journal:       journal,
+		applyEvent:    applyEvent,
+		state:         initial,
+
+		snapshotErrorHandler: defaultPersistentActorSnapshotErrorHandler(persistenceID),
+	}
+	err := newOne.recover()
+	if err != nil {
+		return nil, err
+	}
+
+	newOne.ActorDef = ActorNewByMailboxGenerics(func(_ *ActorDef[T], message T) {
+		command(newOne, message)
+	}, mailbox, map[string]interface{}{})
+	return newOne, nil
+}
+
+// recover Recover the state from the latest snapshot & the later events
+func (actorSelf *PersistentActorDef[T, E, S]) recover() error {
+	snapshot, err := actorSelf.journal.LoadSnapshot(actorSelf.persistenceID)
+	if err != nil {
+		return err
+	}
+	if snapshot != nil {
+		actorSelf.state = snapshot.State
+		actorSelf.sequenceNr = snapshot.SequenceNr
+		actorSelf.lastSnapshotSequenceNr = snapshot.SequenceNr
+	}
+
+	return actorSelf.journal.Replay(actorSelf.persistenceID, actorSelf.sequenceNr+1, func(sequenceNr int, event E) {
+		actorSelf.state = actorSelf.applyEvent(actorSelf.state, event)
+		actorSelf.sequenceNr = sequenceNr
+	})
+}
+
+// SetSnapshotEvery Save a snapshot every n persisted events(disabled if n <= 0)
+func (actorSelf *PersistentActorDef[T, E, S]) SetSnapshotEvery(n int) *PersistentActorDef[T, E, S] {
+	actorSelf.lock.Lock()
+	actorSelf.snapshotEvery = n
+	actorSelf.lock.Unlock()
+
+	return actorSelf
+}
+
+// SetSnapshotErrorHandler Set the handler of the errors of the snapshots saved by SetSnapshotEvery(logged by default)
+func (actorSelf *PersistentActorDef[T, E, S]) SetSnapshotErrorHandler(snapshotErrorHandler func(err error)) *PersistentActorDef[T, E, S] {
+	actorSelf.lock.Lock()
+	actorSelf.snapshotErrorHandler = snapshotErrorHandler
+	actorSelf.lock.Unlock()
+
+	return actorSelf
+}
+
+// Persist Append the event to the journal & then apply it to the state(call it inside its command effect)
+func (actorSelf *PersistentActorDef[T, E, S]) Persist(event E) error {
+	actorSelf.lock.RLock()
+	sequenceNr := actorSelf.sequenceNr + 1
+	actorSelf.lock.RUnlock()
+
+	err := actorSelf.journal.Append(actorSelf.persistenceID, sequenceNr, event)
+	if err != nil {
+		return err
+	}
+
+	actorSelf.lock.Lock()
+	actorSelf.state = actorSelf.applyEvent(actorSelf.state, event)
+	actorSelf.sequenceNr = sequenceNr
+	isSnapshotDue := actorSelf.snapshotEvery > 0 && sequenceNr-actorSelf.lastSnapshotSequenceNr >= actorSelf.snapshotEvery
+	snapshotErrorHandler := actorSelf.snapshotErrorHandler
+	actorSelf.lock.Unlock()
+
+	if isSnapshotDue {
+		// The event has been persisted, a failed snapshot is retried at the next event
+		err = actorSelf.SaveSnapshot()
+		if err != nil && snapshotErrorHandler != nil {
+			snapshotErrorHandler(err)
+		}
+	}
+	return nil
+}
+
+// SaveSnapshot Save the snapshot of the current state
+func (actorSelf *PersistentActorDef[T, E, S]) SaveSnapshot() error {
+	actorSelf.lock.RLock()
+	snapshot := ActorSnapshot[S]{SequenceNr: actorSelf.sequenceNr, State: actorSelf.state}
+	actorSelf.lock.RUnlock()
+
+	err := actorSelf.journal.SaveSnapshot(actorSelf.persistenceID, snapshot)
+	if err != nil {
+		return err
+	}
+
+	actorSelf.lock.Lock()
+	if snapshot.SequenceNr > actorSelf.lastSnapshotSequenceNr {
+		actorSelf.lastSnapshotSequenceNr = snapshot.SequenceNr
+	}
+	actorSelf.lock.Unlock()
+	return nil
+}
+
+// GetState Get the current state
+func (actorSelf *PersistentActorDef[T, E, S]) GetState() S {
+	actorSelf.lock.RLock()
+	defer actorSelf.lock.RUnlock()
+
+	return actorSelf.state
+}
+
+// GetSequenceNr Get the sequenceNr of the last persisted event
+func (actorSelf *PersistentActorDef[T, E, S]) GetSequenceNr() int {
+	actorSelf.lock.RLock()
+	defer actorSelf.lock.RUnlock()
+
+	return actorSelf.sequenceNr
+}
+
+// GetPersistenceID Get the persistenceID
+func (actorSelf *PersistentActorDef[T, E, S]) GetPersistenceID() string {
+	return actorSelf.persistenceID
+}
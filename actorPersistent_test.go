@@ -0,0 +1,147 @@
+package fpgo
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testAccountState struct {
+	Balance int
+	Count   int
+}
+
+type testAccountCommand struct {
+	amount int
+	ask    *AskDef[int, error]
+}
+
+func newTestAccount(t *testing.T, journal ActorJournal[int, testAccountState]) *PersistentActorDef[testAccountCommand, int, testAccountState] {
+	account, err := PersistentActorNewGenerics("account/1", journal, testAccountState{}, func(state testAccountState, event int) testAccountState {
+		return testAccountState{Balance: state.Balance + event, Count: state.Count + 1}
+	}, func(self *PersistentActorDef[testAccountCommand, int, testAccountState], message testAccountCommand) {
+		message.ask.Reply(self.Persist(message.amount))
+	})
+	assert.NoError(t, err)
+	return account
+}
+
+func deposit(t *testing.T, account *PersistentActorDef[testAccountCommand, int, testAccountState], amount int) {
+	err, askErr := ActorAskByGenerics[int, error, testAccountCommand](context.Background(), account, amount, func(ask *AskDef[int, error]) testAccountCommand {
+		return testAccountCommand{amount: ask.Message, ask: ask}
+	})
+	assert.NoError(t, askErr)
+	assert.NoError(t, err)
+}
+
+func testPersistentActor(t *testing.T, journal ActorJournal[int, testAccountState]) {
+	account := newTestAccount(t, journal)
+	for i := 1; i <= 5; i++ {
+		deposit(t, account, i)
+	}
+	assert.Equal(t, testAccountState{Balance: 15, Count: 5}, account.GetState())
+	assert.Equal(t, 5, account.GetSequenceNr())
+	account.Shutdown(context.Background())
+
+	// Recovered by replaying
+	account = newTestAccount(t, journal)
+	assert.Equal(t, testAccountState{Balance: 15, Count: 5}, account.GetState())
+	snapshot, err := journal.LoadSnapshot("account/1")
+	assert.NoError(t, err)
+	assert.Nil(t, snapshot)
+	account.SetSnapshotEvery(3)
+	deposit(t, account, 10)
+	deposit(t, account, 20)
+	deposit(t, account, 30)
+	snapshot, err = journal.LoadSnapshot("account/1")
+	assert.NoError(t, err)
+	assert.Equal(t, &ActorSnapshot[testAccountState]{SequenceNr: 6, State: testAccountState{Balance: 25, Count: 6}}, snapshot)
+	account.Shutdown(context.Background())
+
+	// Recovered by the snapshot & the later events
+	replayed := []int{}
+	journal.Replay("account/1", snapshot.SequenceNr+1, func(sequenceNr int, event int) {
+		replayed = append(replayed, event)
+	})
+	assert.Equal(t, []int{20, 30}, replayed)
+	account = newTestAccount(t, journal)
+	assert.Equal(t, testAccountState{Balance: 75, Count: 8}, account.GetState())
+	assert.Equal(t, 8, account.GetSequenceNr())
+
+	// Another writer of the same persistenceID
+	assert.Equal(t, ErrActorJournalSequenceConflict, journal.Append("account/1", 8, 1))
+	account.Shutdown(context.Background())
+
+	// An older snapshot saved later doesn't overwrite the newer one
+	assert.NoError(t, journal.SaveSnapshot("account/1", ActorSnapshot[testAccountState]{SequenceNr: 8, State: testAccountState{Balance: 75, Count: 8}}))
+	assert.NoError(t, journal.SaveSnapshot("account/1", ActorSnapshot[testAccountState]{SequenceNr: 7, State: testAccountState{Balance: 45, Count: 7}}))
+	snapshot, err = journal.LoadSnapshot("account/1")
+	assert.NoError(t, err)
+	assert.Equal(t, &ActorSnapshot[testAccountState]{SequenceNr: 8, State: testAccountState{Balance: 75, Count: 8}}, snapshot)
+}
+
+func TestPersistentActorMemoryJournal(t *testing.T) {
+	testPersistentActor(t, ActorMemoryJournalNewGenerics[int, testAccountState]())
+}
+
+func TestPersistentActorFileJournal(t *testing.T) {
+	dir := t.TempDir()
+	journal, err := ActorFileJournalNewGenerics[int, testAccountState](dir)
+	assert.NoError(t, err)
+	testPersistentActor(t, journal)
+
+	// Reopened
+	journal, err = ActorFileJournalNewGenerics[int, testAccountState](dir)
+	assert.NoError(t, err)
+	account := newTestAccount(t, journal)
+	assert.Equal(t, testAccountState{Balance: 75, Count: 8}, account.GetState())
+	account.Shutdown(context.Background())
+
+	// A torn write at the tail is ignored & truncated
+	path := filepath.Join(dir, "account%2F1.journal")
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	assert.NoError(t, err)
+	file.WriteString(`{"sequenceNr":9,"ev`)
+	file.Close()
+	journal, err = ActorFileJournalNewGenerics[int, testAccountState](dir)
+	assert.NoError(t, err)
+	account = newTestAccount(t, journal)
+	assert.Equal(t, 8, account.GetSequenceNr())
+	deposit(t, account, 100)
+	account.Shutdown(context.Background())
+	account = newTestAccount(t, journal)
+	assert.Equal(t, testAccountState{Balance: 175, Count: 9}, account.GetState())
+	account.Shutdown(context.Background())
+
+	// No temp file is left
+	tmps, err := filepath.Glob(filepath.Join(dir, "*.tmp"))
+	assert.NoError(t, err)
+	assert.Empty(t, tmps)
+}
+
+type testFailedSnapshotJournal struct {
+	*ActorMemoryJournalDef[int, testAccountState]
+}
+
+func (journalSelf testFailedSnapshotJournal) SaveSnapshot(persistenceID string, snapshot ActorSnapshot[testAccountState]) error {
+	return os.ErrPermission
+}
+
+func TestPersistentActorSnapshotError(t *testing.T) {
+	account := newTestAccount(t, testFailedSnapshotJournal{ActorMemoryJournalNewGenerics[int, testAccountState]()})
+	defer account.Shutdown(context.Background())
+	errs := make(chan error, 2)
+	account.SetSnapshotEvery(1).SetSnapshotErrorHandler(func(err error) {
+		errs <- err
+	})
+
+	// The events are persisted, the failed snapshots are retried
+	deposit(t, account, 1)
+	deposit(t, account, 2)
+	assert.Equal(t, os.ErrPermission, <-errs)
+	assert.Equal(t, os.ErrPermission, <-errs)
+	assert.Equal(t, testAccountState{Balance: 3, Count: 2}, account.GetState())
+}
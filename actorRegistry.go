@@ -0,0 +1,106 @@
+package fpgo
+
+import (
+	"context"
+	"sync"
+)
+
+// ActorRegistryDef[T] The registry of Actors by their paths(e.g. "/user/orders/worker-3")
+type ActorRegistryDef[T any] struct {
+	lock   sync.RWMutex
+	actors map[string]*ActorDef[T]
+}
+
+// NewRegistry New ActorRegistry instance
+func (actorSelf *ActorDef[T]) NewRegistry() *ActorRegistryDef[T] {
+	return ActorRegistryNewGenerics[T]()
+}
+
+// ActorRegistryNewGenerics New ActorRegistry instance
+func ActorRegistryNewGenerics[T any]() *ActorRegistryDef[T] {
+	return &ActorRegistryDef[T]{
+		actors: map[string]*ActorDef[T]{},
+	}
+}
+
+// ActorOf New a root Actor registered as "/<name>"(its descendants are registered too)
+func (registrySelf *ActorRegistryDef[T]) ActorOf(name string, effect func(*ActorDef[T], T)) (*ActorDef[T], error) {
+	return registrySelf.ActorOfByMailbox(name, effect, ActorChannelMailboxNewGenerics(make(chan T)), map[string]interface{}{})
+}
+
+// ActorOfByMailbox New a root Actor by the ActorMailbox registered as "/<name>"(its descendants are registered too)
+func (registrySelf *ActorRegistryDef[T]) ActorOfByMailbox(name string, effect func(*ActorDef[T], T), mailbox ActorMailbox[T], context map[string]interface{}) (*ActorDef[T], error) {
+	if !isActorNameValid(name) {
+		return nil, ErrActorNameInvalid
+	}
+
+	return actorNewGenerics(effect, mailbox, context, nil, registrySelf, name)
+}
+
+// Lookup Get the running Actor by the path(nil if not found)
+func (registrySelf *ActorRegistryDef[T]) Lookup(path string) *ActorDef[T] {
+	registrySelf.lock.RLock()
+	defer registrySelf.lock.RUnlock()
+
+	return registrySelf.actors[path]
+}
+
+// Watch Let the watcher receive ActorLifecycleWatchedTerminated when the Actor of the path is closed
+func (registrySelf *ActorRegistryDef[T]) Watch(path string, watcher *ActorDef[T]) error {
+	actor := registrySelf.Lookup(path)
+	if actor == nil {
+		return ErrActorNotFound
+	}
+
+	actor.Watch(watcher)
+	return nil
+}
+
+// Shutdown Shutdown the Actor of the path & its descendants gracefully
+func (registrySelf *ActorRegistryDef[T]) Shutdown(ctx context.Context, path string) error {
+	actor := registrySelf.Lookup(path)
+	if actor == nil {
+		return ErrActorNotFound
+	}
+
+	return actor.Shutdown(ctx)
+}
+
+// ShutdownAll Shutdown all root Actors & their descendants gracefully
+func (registrySelf *ActorRegistryDef[T]) ShutdownAll(ctx context.Context) error {
+	registrySelf.lock.RLock()
+	roots := []*ActorDef[T]{}
+	for _, actor := range registrySelf.actors {
+		if actor.GetParent() == nil {
+			roots = append(roots, actor)
+		}
+	}
+	registrySelf.lock.RUnlock()
+
+	for _, actor := range roots {
+		if err := actor.Shutdown(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (registrySelf *ActorRegistryDef[T]) register(actor *ActorDef[T]) error {
+	registrySelf.lock.Lock()
+	defer registrySelf.lock.Unlock()
+
+	if registrySelf.actors[actor.path] != nil {
+		return ErrActorNameConflict
+	}
+	registrySelf.actors[actor.path] = actor
+	return nil
+}
+
+func (registrySelf *ActorRegistryDef[T]) unregister(actor *ActorDef[T]) {
+	registrySelf.lock.Lock()
+	defer registrySelf.lock.Unlock()
+
+	if registrySelf.actors[actor.path] == actor {
+		delete(registrySelf.actors, actor.path)
+	}
+}
@@ -0,0 +1,108 @@
+package fpgo
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActorUniqueID(t *testing.T) {
+	ids := map[time.Time]bool{}
+	root := Actor.New(func(self *ActorDef[interface{}], input interface{}) {})
+	for i := 0; i < 1000; i++ {
+		child := root.Spawn(func(self *ActorDef[interface{}], input interface{}) {})
+		assert.False(t, ids[child.GetID()])
+		ids[child.GetID()] = true
+	}
+	assert.Equal(t, 1000, len(root.children))
+	root.Shutdown(context.Background())
+}
+
+func TestActorRegistry(t *testing.T) {
+	var err error
+	nop := func(self *ActorDef[string], input string) {}
+	registry := ActorRegistryNewGenerics[string]()
+
+	user, err := registry.ActorOf("user", nop)
+	assert.NoError(t, err)
+	_, err = registry.ActorOf("user", nop)
+	assert.Equal(t, ErrActorNameConflict, err)
+	_, err = registry.ActorOf("a/b", nop)
+	assert.Equal(t, ErrActorNameInvalid, err)
+	orders, err := user.SpawnNamed("orders", nop)
+	assert.NoError(t, err)
+	_, err = user.SpawnNamed("orders", nop)
+	assert.Equal(t, ErrActorNameConflict, err)
+	_, err = user.SpawnByMailbox("$1", nop, ActorUnboundedMailboxNewGenerics[string](), map[string]interface{}{})
+	assert.Equal(t, ErrActorNameInvalid, err)
+	_, err = user.SpawnByMailbox("a/b", nop, ActorUnboundedMailboxNewGenerics[string](), map[string]interface{}{})
+	assert.Equal(t, ErrActorNameInvalid, err)
+	worker3, err := orders.SpawnNamed("worker-3", nop)
+	assert.NoError(t, err)
+	unnamed := orders.Spawn(nop)
+
+	// Lookup
+	assert.Equal(t, "/user/orders/worker-3", worker3.GetPath())
+	assert.Equal(t, "worker-3", worker3.GetName())
+	assert.Equal(t, worker3, registry.Lookup("/user/orders/worker-3"))
+	assert.Equal(t, orders, registry.Lookup("/user/orders"))
+	assert.Equal(t, unnamed, registry.Lookup(unnamed.GetPath()))
+	assert.Equal(t, worker3, orders.GetChildByName("worker-3"))
+	assert.Nil(t, registry.Lookup("/user/nobody"))
+
+	// Watch
+	var lock sync.Mutex
+	watched := []string{}
+	watcher, _ := registry.ActorOf("watcher", nop)
+	watcher.SetLifecycleEffect(func(self *ActorDef[string], message ActorLifecycleMessage[string]) {
+		if message.Type == ActorLifecycleWatchedTerminated {
+			lock.Lock()
+			watched = append(watched, message.Child.GetPath())
+			lock.Unlock()
+		}
+	})
+	assert.NoError(t, registry.Watch("/user/orders/worker-3", watcher))
+	assert.Equal(t, ErrActorNotFound, registry.Watch("/user/nobody", watcher))
+	worker3.Close()
+	assert.Eventually(t, func() bool {
+		lock.Lock()
+		defer lock.Unlock()
+		return len(watched) == 1
+	}, time.Second, time.Millisecond)
+	assert.Nil(t, registry.Lookup("/user/orders/worker-3"))
+	// The name could be reused after the termination
+	worker3, err = orders.SpawnNamed("worker-3", nop)
+	assert.NoError(t, err)
+	// Watching a terminated one
+	closed, _ := orders.SpawnNamed("closed", nop)
+	closed.Shutdown(context.Background())
+	closed.Watch(watcher)
+	assert.Eventually(t, func() bool {
+		lock.Lock()
+		defer lock.Unlock()
+		return len(watched) == 2
+	}, time.Second, time.Millisecond)
+
+	// Shutdown recursively
+	assert.NoError(t, registry.Shutdown(context.Background(), "/user"))
+	assert.True(t, user.IsClosed())
+	assert.True(t, orders.IsClosed())
+	assert.True(t, worker3.IsClosed())
+	assert.True(t, unnamed.IsClosed())
+	assert.Nil(t, registry.Lookup("/user/orders"))
+	assert.Equal(t, watcher, registry.Lookup("/watcher"))
+	assert.NoError(t, registry.ShutdownAll(context.Background()))
+	assert.Nil(t, registry.Lookup("/watcher"))
+
+	// Shutdown timeout: the Actor is busy
+	blocking, _ := registry.ActorOf("blocking", func(self *ActorDef[string], input string) {
+		time.Sleep(50 * time.Millisecond)
+	})
+	blocking.Send("block")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, blocking.Shutdown(ctx))
+}
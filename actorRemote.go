@@ -0,0 +1,596 @@
+package fpgo
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"sync"
+	"time"
+)
+
+// ErrRemoteIsClosed The remote connection is closed
+var ErrRemoteIsClosed = fmt.Errorf("ErrRemoteIsClosed")
+
+// ErrRemoteFrameTooLarge The frame exceeds remoteFrameSizeMaximum
+var ErrRemoteFrameTooLarge = fmt.Errorf("ErrRemoteFrameTooLarge")
+
+// remoteFrameSizeMaximum The maximum size of a frame
+const remoteFrameSizeMaximum = 64 << 20
+
+// remoteKnownErrors The errors keeping their identities across the wire
+var remoteKnownErrors = []error{
+	ErrActorNotFound,
+	ErrQueueIsClosed,
+	ErrQueueIsFull,
+	context.DeadlineExceeded,
+	context.Canceled,
+}
+
+// RemoteCodec The codec of the messages across the wire
+type RemoteCodec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+type remoteJSONCodec struct{}
+
+func (codecSelf remoteJSONCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (codecSelf remoteJSONCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+type remoteGobCodec struct{}
+
+func (codecSelf remoteGobCodec) Marshal(v interface{}) ([]byte, error) {
+	var buffer bytes.Buffer
+	err := gob.NewEncoder(&buffer).Encode(v)
+	return buffer.Bytes(), err
+}
+
+func (codecSelf remoteGobCodec) Unmarshal(data []byte, v interface{}) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+// RemoteJSONCodec The RemoteCodec by encoding/json
+var RemoteJSONCodec RemoteCodec = remoteJSONCodec{}
+
+// RemoteGobCodec The RemoteCodec by encoding/gob(register the concrete types of interface values by gob.Register)
+var RemoteGobCodec RemoteCodec = remoteGobCodec{}
+
+type remoteFrameKind int
+
+const (
+	remoteFrameTell remoteFrameKind = iota
+	remoteFrameAsk
+	remoteFrameReply
+)
+
+// remoteFrame The envelope across the wire(the Payload is marshaled by the RemoteCodec separately)
+type remoteFrame struct {
+	Kind    remoteFrameKind
+	Name    string
+	AskID   uint64
+	Timeout time.Duration
+	Error   string
+	Payload []byte
+}
+
+func writeRemoteFrame(writer io.Writer, codec RemoteCodec, frame remoteFrame) error {
+	data, err := codec.Marshal(frame)
+	if err != nil {
+		return err
+	}
+	if len(data) > remoteFrameSizeMaximum {
+		return ErrRemoteFrameTooLarge
+	}
+
+	header := make([]byte, 4)
+	binary.BigEndian.PutUint32(header, uint32(len(data)))
+	_, err = writer.Write(append(header, data...))
+	return err
+}
+
+func readRemoteFrame(reader io.Reader, codec RemoteCodec) (remoteFrame, error) {
+	var frame remoteFrame
+	header := make([]byte, 4)
+	_, err := io.ReadFull(reader, header)
+	if err != nil {
+		return frame, err
+	}
+	size := binary.BigEndian.Uint32(header)
+	if size > remoteFrameSizeMaximum {
+		return frame, ErrRemoteFrameTooLarge
+	}
+
+	data := make([]byte, size)
+	_, err = io.ReadFull(reader, data)
+	if err != nil {
+		return frame, err
+	}
+	err = codec.Unmarshal(data, &frame)
+	return frame, err
+}
+
+func remoteErrorToString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+func remoteErrorFromString(message string) error {
+	if message == "" {
+		return nil
+	}
+	for _, err := range remoteKnownErrors {
+		if err.Error() == message {
+			return err
+		}
+	}
+	return errors.New(message)
+}
+
+// RemoteServer
+
+type remoteServerHandler struct {
+	tell func(codec RemoteCodec, payload []byte) error
+	ask  func(ctx context.Context, codec RemoteCodec, payload []byte) ([]byte, error)
+}
+
+// remoteTellQueueSize The maximum pending tells of a name on a connection(the reader waits when it's full)
+const remoteTellQueueSize = 64
+
+// remoteTellQueue The ordered tells of a name on a connection, served by its own goroutine(thus a blocking Actor doesn't stall the reader until it's full)
+type remoteTellQueue struct {
+	name    string
+	pending chan func() error
+}
+
+func newRemoteTellQueue(name string) *remoteTellQueue {
+	newOne := &remoteTellQueue{
+		name:    name,
+		pending: make(chan func() error, remoteTellQueueSize),
+	}
+	go newOne.run()
+
+	return newOne
+}
+
+// push Queue the tell, false if the server is closed while waiting for the space
+func (queueSelf *remoteTellQueue) push(tell func() error, closedCh chan bool) bool {
+	select {
+	case queueSelf.pending <- tell:
+		return true
+	case <-closedCh:
+		return false
+	}
+}
+
+func (queueSelf *remoteTellQueue) run() {
+	for tell := range queueSelf.pending {
+		// Nobody waits for the result of a tell
+		if err := tell(); err != nil {
+			log.Printf("remote: tell %s failed: %v\n", queueSelf.name, err)
+		}
+	}
+}
+
+// close Stop the goroutine after the queued tells(called by the reader only)
+func (queueSelf *remoteTellQueue) close() {
+	close(queueSelf.pending)
+}
+
+// RemoteServerDef Expose Actors to other processes by their names over TCP/Unix sockets
+type RemoteServerDef struct {
+	listener net.Listener
+	codec    RemoteCodec
+
+	lock     sync.RWMutex
+	isClosed bool
+	closedCh chan bool
+	handlers map[string]*remoteServerHandler
+	conns    map[net.Conn]bool
+	wg       sync.WaitGroup
+}
+
+// NewRemoteServer Listen on the network("tcp", "unix", etc) & the address, serving in the background
+func NewRemoteServer(network string, address string, codec RemoteCodec) (*RemoteServerDef, error) {
+	listener, err := net.Listen(network, address)
+	if err != nil {
+		return nil, err
+	}
+
+	newOne := &RemoteServerDef{
+		listener: listener,
+		codec:    codec,
+		closedCh: make(chan bool),
+		handlers: map[string]*remoteServerHandler{},
+		conns:    map[net.Conn]bool{},
+	}
+	newOne.wg.Add(1)
+	go newOne.serve()
+
+	return newOne, nil
+}
+
+// RemoteRegisterGenerics Expose the Actor by the name for the messages sent(told) remotely
+func RemoteRegisterGenerics[T any](server *RemoteServerDef, name string, actor ActorHandle[T]) {
+	RemoteRegisterByGenerics(server, name, actor, func(message T) T {
+		return message
+	})
+}
+
+// RemoteRegisterByGenerics Expose the Actor by the name for the messages sent(told) remotely(wrap them into its message type M)
+func RemoteRegisterByGenerics[T any, M any](server *RemoteServerDef, name string, actor ActorHandle[M], wrap func(T) M) {
+	tell := func(codec RemoteCodec, payload []byte) error {
+		var message T
+		err := codec.Unmarshal(payload, &message)
+		if err != nil {
+			return err
+		}
+		return actor.Send(wrap(message))
+	}
+	server.updateHandler(name, func(handler *remoteServerHandler) {
+		handler.tell = tell
+	})
+}
+
+// RemoteRegisterAskGenerics Expose the Actor by the name for the remote Asks
+func RemoteRegisterAskGenerics[T any, R any](server *RemoteServerDef, name string, actor ActorHandle[*AskDef[T, R]]) {
+	RemoteRegisterAskByGenerics(server, name, actor, func(ask *AskDef[T, R]) *AskDef[T, R] {
+		return ask
+	})
+}
+
+// RemoteRegisterAskByGenerics Expose the Actor by the name for the remote Asks(wrap the AskDef into its message type M)
+func RemoteRegisterAskByGenerics[T any, R any, M any](server *RemoteServerDef, name string, actor ActorHandle[M], wrap func(*AskDef[T, R]) M) {
+	ask := func(ctx context.Context, codec RemoteCodec, payload []byte) ([]byte, error) {
+		var message T
+		err := codec.Unmarshal(payload, &message)
+		if err != nil {
+			return nil, err
+		}
+		result, err := ActorAskByGenerics(ctx, actor, message, wrap)
+		if err != nil {
+			return nil, err
+		}
+		return codec.Marshal(result)
+	}
+	server.updateHandler(name, func(handler *remoteServerHandler) {
+		handler.ask = ask
+	})
+}
+
+// Unregister Stop exposing the Actor of the name
+func (serverSelf *RemoteServerDef) Unregister(name string) {
+	serverSelf.lock.Lock()
+	defer serverSelf.lock.Unlock()
+
+	delete(serverSelf.handlers, name)
+}
+
+// updateHandler Replace the handler of the name by an updated copy(handlers are immutable once they're in the map)
+func (serverSelf *RemoteServerDef) updateHandler(name string, update func(*remoteServerHandler)) {
+	serverSelf.lock.Lock()
+	defer serverSelf.lock.Unlock()
+
+	handler := &remoteServerHandler{}
+	if old := serverSelf.handlers[name]; old != nil {
+		*handler = *old
+	}
+	update(handler)
+	serverSelf.handlers[name] = handler
+}
+
+// GetAddr Get the listening address
+func (serverSelf *RemoteServerDef) GetAddr() net.Addr {
+	return serverSelf.listener.Addr()
+}
+
+// Close Stop listening & close all connections
+func (serverSelf *RemoteServerDef) Close() error {
+	serverSelf.lock.Lock()
+	if serverSelf.isClosed {
+		serverSelf.lock.Unlock()
+		return nil
+	}
+	serverSelf.isClosed = true
+	close(serverSelf.closedCh)
+	err := serverSelf.listener.Close()
+	for conn := range serverSelf.conns {
+		conn.Close()
+	}
+	serverSelf.lock.Unlock()
+
+	serverSelf.wg.Wait()
+	return err
+}
+
+func (serverSelf *RemoteServerDef) serve() {
+	defer serverSelf.wg.Done()
+
+	for {
+		conn, err := serverSelf.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		serverSelf.lock.Lock()
+		if serverSelf.isClosed {
+			serverSelf.lock.Unlock()
+			conn.Close()
+			return
+		}
+		serverSelf.conns[conn] = true
+		serverSelf.wg.Add(1)
+		serverSelf.lock.Unlock()
+
+		go serverSelf.serveConn(conn)
+	}
+}
+
+func (serverSelf *RemoteServerDef) serveConn(conn net.Conn) {
+	defer serverSelf.wg.Done()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		// Pending Asks are cancelled
+		cancel()
+		conn.Close()
+		serverSelf.lock.Lock()
+		delete(serverSelf.conns, conn)
+		serverSelf.lock.Unlock()
+	}()
+
+	var writeLock sync.Mutex
+	tellQueues := map[string]*remoteTellQueue{}
+	defer func() {
+		for _, queue := range tellQueues {
+			queue.close()
+		}
+	}()
+	reader := bufio.NewReader(conn)
+	for {
+		frame, err := readRemoteFrame(reader, serverSelf.codec)
+		if err != nil {
+			return
+		}
+
+		serverSelf.lock.RLock()
+		handler := serverSelf.handlers[frame.Name]
+		serverSelf.lock.RUnlock()
+
+		switch frame.Kind {
+		case remoteFrameTell:
+			// Fire & forget(in order per name, the reader waits only if the queue of the name is full)
+			if handler != nil && handler.tell != nil {
+				queue := tellQueues[frame.Name]
+				if queue == nil {
+					queue = newRemoteTellQueue(frame.Name)
+					tellQueues[frame.Name] = queue
+				}
+				tell, payload := handler.tell, frame.Payload
+				if !queue.push(func() error {
+					return tell(serverSelf.codec, payload)
+				}, serverSelf.closedCh) {
+					return
+				}
+			}
+		case remoteFrameAsk:
+			go func(frame remoteFrame) {
+				reply := remoteFrame{Kind: remoteFrameReply, AskID: frame.AskID}
+				if handler == nil || handler.ask == nil {
+					reply.Error = remoteErrorToString(ErrActorNotFound)
+				} else {
+					askCtx, askCancel := ctx, context.CancelFunc(func() {})
+					if frame.Timeout > 0 {
+						askCtx, askCancel = context.WithTimeout(ctx, frame.Timeout)
+					}
+					var err error
+					reply.Payload, err = handler.ask(askCtx, serverSelf.codec, frame.Payload)
+					askCancel()
+					reply.Error = remoteErrorToString(err)
+				}
+
+				writeLock.Lock()
+				defer writeLock.Unlock()
+				writeRemoteFrame(conn, serverSelf.codec, reply)
+			}(frame)
+		}
+	}
+}
+
+// RemoteClient
+
+// RemoteClientDef The connection to a RemoteServer
+type RemoteClientDef struct {
+	conn  net.Conn
+	codec RemoteCodec
+
+	writeLock sync.Mutex
+
+	lock      sync.Mutex
+	isClosed  bool
+	nextAskID uint64
+	pending   map[uint64]chan remoteFrame
+}
+
+// DialRemote Connect to the RemoteServer on the network("tcp", "unix", etc) & the address
+func DialRemote(network string, address string, codec RemoteCodec) (*RemoteClientDef, error) {
+	conn, err := net.Dial(network, address)
+	if err != nil {
+		return nil, err
+	}
+
+	newOne := &RemoteClientDef{
+		conn:    conn,
+		codec:   codec,
+		pending: map[uint64]chan remoteFrame{},
+	}
+	go newOne.receive()
+
+	return newOne, nil
+}
+
+// Close Close the connection(pending Asks return ErrRemoteIsClosed)
+func (clientSelf *RemoteClientDef) Close() error {
+	return clientSelf.conn.Close()
+}
+
+// IsClosed Is the connection closed
+func (clientSelf *RemoteClientDef) IsClosed() bool {
+	clientSelf.lock.Lock()
+	defer clientSelf.lock.Unlock()
+
+	return clientSelf.isClosed
+}
+
+func (clientSelf *RemoteClientDef) receive() {
+	reader := bufio.NewReader(clientSelf.conn)
+	for {
+		frame, err := readRemoteFrame(reader, clientSelf.codec)
+		if err != nil {
+			break
+		}
+
+		clientSelf.lock.Lock()
+		ch := clientSelf.pending[frame.AskID]
+		delete(clientSelf.pending, frame.AskID)
+		clientSelf.lock.Unlock()
+		// Late replies are discarded
+		if ch != nil {
+			ch <- frame
+		}
+	}
+
+	clientSelf.conn.Close()
+	clientSelf.lock.Lock()
+	clientSelf.isClosed = true
+	pending := clientSelf.pending
+	clientSelf.pending = map[uint64]chan remoteFrame{}
+	clientSelf.lock.Unlock()
+	for _, ch := range pending {
+		close(ch)
+	}
+}
+
+func (clientSelf *RemoteClientDef) write(frame remoteFrame) error {
+	if clientSelf.IsClosed() {
+		return ErrRemoteIsClosed
+	}
+
+	clientSelf.writeLock.Lock()
+	defer clientSelf.writeLock.Unlock()
+	err := writeRemoteFrame(clientSelf.conn, clientSelf.codec, frame)
+	if err != nil && err != ErrRemoteFrameTooLarge {
+		return ErrRemoteIsClosed
+	}
+	return err
+}
+
+// tell Send the payload to the Actor of the name
+func (clientSelf *RemoteClientDef) tell(name string, message interface{}) error {
+	payload, err := clientSelf.codec.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	return clientSelf.write(remoteFrame{Kind: remoteFrameTell, Name: name, Payload: payload})
+}
+
+// ask Ask the Actor of the name & wait for the reply payload
+func (clientSelf *RemoteClientDef) ask(ctx context.Context, name string, message interface{}) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	payload, err := clientSelf.codec.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+
+	frame := remoteFrame{Kind: remoteFrameAsk, Name: name, Payload: payload}
+	if deadline, ok := ctx.Deadline(); ok {
+		frame.Timeout = time.Until(deadline)
+		if frame.Timeout <= 0 {
+			return nil, context.DeadlineExceeded
+		}
+	}
+	ch := make(chan remoteFrame, 1)
+	clientSelf.lock.Lock()
+	if clientSelf.isClosed {
+		clientSelf.lock.Unlock()
+		return nil, ErrRemoteIsClosed
+	}
+	clientSelf.nextAskID++
+	frame.AskID = clientSelf.nextAskID
+	clientSelf.pending[frame.AskID] = ch
+	clientSelf.lock.Unlock()
+	defer func() {
+		clientSelf.lock.Lock()
+		delete(clientSelf.pending, frame.AskID)
+		clientSelf.lock.Unlock()
+	}()
+
+	err = clientSelf.write(frame)
+	if err != nil {
+		return nil, err
+	}
+	select {
+	case reply, ok := <-ch:
+		if !ok {
+			return nil, ErrRemoteIsClosed
+		}
+		if reply.Error != "" {
+			return nil, remoteErrorFromString(reply.Error)
+		}
+		return reply.Payload, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// RemoteActorRefDef[T] The ActorHandle of a remote Actor
+type RemoteActorRefDef[T any] struct {
+	client *RemoteClientDef
+	name   string
+}
+
+// RemoteActorRefGenerics Get the ActorHandle of the remote Actor registered by the name
+func RemoteActorRefGenerics[T any](client *RemoteClientDef, name string) *RemoteActorRefDef[T] {
+	return &RemoteActorRefDef[T]{
+		client: client,
+		name:   name,
+	}
+}
+
+// Send Send a message to the remote Actor(ErrRemoteIsClosed if the connection is closed)
+func (refSelf *RemoteActorRefDef[T]) Send(message T) error {
+	return refSelf.client.tell(refSelf.name, message)
+}
+
+// GetName Get the registered name of the remote Actor
+func (refSelf *RemoteActorRefDef[T]) GetName() string {
+	return refSelf.name
+}
+
+// RemoteAskGenerics Ask the remote Actor registered by RemoteRegisterAskGenerics(or RemoteRegisterAskByGenerics), returns ctx.Err() if ctx is done before the reply
+func RemoteAskGenerics[T any, R any](ctx context.Context, ref *RemoteActorRefDef[T], message T) (R, error) {
+	var result R
+	payload, err := ref.client.ask(ctx, ref.name, message)
+	if err != nil {
+		return result, err
+	}
+
+	err = ref.client.codec.Unmarshal(payload, &result)
+	return result, err
+}
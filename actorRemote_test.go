@@ -0,0 +1,208 @@
+package fpgo
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type remoteTestOrder struct {
+	ID    int
+	Items []string
+}
+
+func TestActorRemoteTCP(t *testing.T) {
+	var err error
+	var lock sync.Mutex
+	received := []remoteTestOrder{}
+	wg := sync.WaitGroup{}
+	wg.Add(2)
+	actual := ActorNewGenerics(func(self *ActorDef[interface{}], input interface{}) {
+		switch message := input.(type) {
+		case remoteTestOrder:
+			lock.Lock()
+			received = append(received, message)
+			lock.Unlock()
+			wg.Done()
+		case *AskDef[remoteTestOrder, int]:
+			message.Reply(len(message.Message.Items))
+		}
+	})
+	defer actual.Close()
+
+	server, err := NewRemoteServer("tcp", "127.0.0.1:0", RemoteJSONCodec)
+	assert.NoError(t, err)
+	defer server.Close()
+	RemoteRegisterByGenerics[remoteTestOrder, interface{}](server, "orders", actual, func(order remoteTestOrder) interface{} {
+		return order
+	})
+	RemoteRegisterAskByGenerics[remoteTestOrder, int, interface{}](server, "orders", actual, func(ask *AskDef[remoteTestOrder, int]) interface{} {
+		return ask
+	})
+
+	client, err := DialRemote("tcp", server.GetAddr().String(), RemoteJSONCodec)
+	assert.NoError(t, err)
+	defer client.Close()
+	orders := RemoteActorRefGenerics[remoteTestOrder](client, "orders")
+
+	// Tell
+	assert.NoError(t, orders.Send(remoteTestOrder{ID: 1, Items: []string{"a"}}))
+	assert.NoError(t, orders.Send(remoteTestOrder{ID: 2, Items: []string{"b", "c"}}))
+	wg.Wait()
+	assert.Equal(t, []remoteTestOrder{{ID: 1, Items: []string{"a"}}, {ID: 2, Items: []string{"b", "c"}}}, received)
+
+	// Ask
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	count, err := RemoteAskGenerics[remoteTestOrder, int](ctx, orders, remoteTestOrder{ID: 3, Items: []string{"a", "b", "c"}})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	// Not found
+	_, err = RemoteAskGenerics[remoteTestOrder, int](ctx, RemoteActorRefGenerics[remoteTestOrder](client, "unknown"), remoteTestOrder{})
+	assert.Equal(t, ErrActorNotFound, err)
+
+	// A blocking Actor doesn't stall the connection(registered while it's live)
+	block := make(chan bool)
+	slow := ActorNewByOptionsGenerics(func(self *ActorDef[string], input string) {
+		<-block
+	}, make(chan string), map[string]interface{}{})
+	defer slow.Close()
+	RemoteRegisterGenerics[string](server, "slow", slow)
+	slowRef := RemoteActorRefGenerics[string](client, "slow")
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, slowRef.Send("blocked"))
+	}
+	count, err = RemoteAskGenerics[remoteTestOrder, int](ctx, orders, remoteTestOrder{ID: 4, Items: []string{"a"}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	close(block)
+
+	// Closed
+	client.Close()
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, ErrRemoteIsClosed, orders.Send(remoteTestOrder{ID: 5}))
+	_, err = RemoteAskGenerics[remoteTestOrder, int](ctx, orders, remoteTestOrder{})
+	assert.Equal(t, ErrRemoteIsClosed, err)
+}
+
+func TestActorRemoteUnixGob(t *testing.T) {
+	var err error
+	actual := ActorNewGenerics(func(self *ActorDef[*AskDef[string, string]], ask *AskDef[string, string]) {
+		if ask.Message == "slow" {
+			time.Sleep(200 * time.Millisecond)
+		}
+		ask.Reply("echo:" + ask.Message)
+	})
+	defer actual.Close()
+
+	address := filepath.Join(t.TempDir(), "remote.sock")
+	server, err := NewRemoteServer("unix", address, RemoteGobCodec)
+	assert.NoError(t, err)
+	defer server.Close()
+	RemoteRegisterAskGenerics[string, string](server, "echo", actual)
+
+	client, err := DialRemote("unix", address, RemoteGobCodec)
+	assert.NoError(t, err)
+	defer client.Close()
+	echo := RemoteActorRefGenerics[string](client, "echo")
+
+	// Concurrent Asks are matched by their replies
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			result, err := RemoteAskGenerics[string, string](context.Background(), echo, strconv.Itoa(i))
+			assert.NoError(t, err)
+			assert.Equal(t, "echo:"+strconv.Itoa(i), result)
+		}(i)
+	}
+	wg.Wait()
+
+	// Timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = RemoteAskGenerics[string, string](ctx, echo, "slow")
+	assert.Equal(t, context.DeadlineExceeded, err)
+	// The late reply is discarded
+	result, err := RemoteAskGenerics[string, string](context.Background(), echo, "after")
+	assert.NoError(t, err)
+	assert.Equal(t, "echo:after", result)
+
+	// The server is closed
+	server.Close()
+	_, err = RemoteAskGenerics[string, string](context.Background(), echo, "closed")
+	assert.Equal(t, ErrRemoteIsClosed, err)
+}
+
+func TestActorRemoteTellBackpressure(t *testing.T) {
+	var err error
+	server, err := NewRemoteServer("tcp", "127.0.0.1:0", RemoteJSONCodec)
+	assert.NoError(t, err)
+	client, err := DialRemote("tcp", server.GetAddr().String(), RemoteJSONCodec)
+	assert.NoError(t, err)
+	defer client.Close()
+
+	// The failed sends are logged
+	var logLock sync.Mutex
+	logs := bytes.Buffer{}
+	log.SetOutput(writerFunc(func(p []byte) (int, error) {
+		logLock.Lock()
+		defer logLock.Unlock()
+		return logs.Write(p)
+	}))
+	defer log.SetOutput(os.Stderr)
+	closed := ActorNewGenerics(func(self *ActorDef[string], input string) {})
+	closed.Close()
+	RemoteRegisterGenerics[string](server, "closed", closed)
+	assert.NoError(t, RemoteActorRefGenerics[string](client, "closed").Send("lost"))
+	assert.Eventually(t, func() bool {
+		logLock.Lock()
+		defer logLock.Unlock()
+		return strings.Contains(logs.String(), "remote: tell closed failed")
+	}, time.Second, time.Millisecond)
+
+	// The queue is bounded: the reader waits for a blocking Actor, & it's released by Close
+	block := make(chan bool)
+	defer close(block)
+	blocking := ActorNewByOptionsGenerics(func(self *ActorDef[string], input string) {
+		<-block
+	}, make(chan string), map[string]interface{}{})
+	defer blocking.Close()
+	RemoteRegisterGenerics[string](server, "blocking", blocking)
+	blockingRef := RemoteActorRefGenerics[string](client, "blocking")
+	for i := 0; i < remoteTellQueueSize+10; i++ {
+		assert.NoError(t, blockingRef.Send("blocked"))
+	}
+	// The reader is waiting, thus the later frames aren't read
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = RemoteAskGenerics[string, string](ctx, RemoteActorRefGenerics[string](client, "none"), "waiting")
+	assert.Equal(t, context.DeadlineExceeded, err)
+	isClosed := make(chan bool)
+	go func() {
+		server.Close()
+		close(isClosed)
+	}()
+	select {
+	case <-isClosed:
+	case <-time.After(time.Second):
+		assert.Fail(t, "blocked")
+	}
+}
+
+type writerFunc func(p []byte) (int, error)
+
+func (writerSelf writerFunc) Write(p []byte) (int, error) {
+	return writerSelf(p)
+}
@@ -0,0 +1,255 @@
+package fpgo
+
+import (
+	"fmt"
+	"hash/fnv"
+	"log"
+	"math/rand"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// ErrRouterHasNoRoutees The router has no routees to route the message(sent to the dead letter)
+var ErrRouterHasNoRoutees = fmt.Errorf("ErrRouterHasNoRoutees")
+
+// RouterStrategy The way a router distributes messages to its routees
+type RouterStrategy int
+
+const (
+	// RouterRoundRobin Send to the routees in turn
+	RouterRoundRobin RouterStrategy = iota
+	// RouterRandom Send to a random routee
+	RouterRandom
+	// RouterConsistentHash Send to the routee chosen by the hash key of the message(the same key goes to the same routee while the pool is unchanged)
+	RouterConsistentHash
+	// RouterSmallestMailbox Send to the routee with the fewest pending messages(the idle ones first)
+	RouterSmallestMailbox
+	// RouterBroadcast Send to all routees
+	RouterBroadcast
+)
+
+// routerVirtualNodes The count of the virtual nodes of a routee on the consistent hash ring
+const routerVirtualNodes = 32
+
+// RouterDef[T] Router Actor inspired by Akka routing, its routees are its supervised children
+type RouterDef[T any] struct {
+	*ActorDef[T]
+
+	strategy    RouterStrategy
+	effect      func(*ActorDef[T], T)
+	newMailbox  func() ActorMailbox[T]
+	hashKey     func(T) string
+	deadLetter  func(T, error)
+	roundRobinN uint64
+
+	lock     sync.RWMutex
+	routees  []*ActorDef[T]
+	ring     []uint32
+	ringNode map[uint32]*ActorDef[T]
+}
+
+// NewRouter New a Router with size routees running the effect(routees use unbounded mailboxes)
+func (actorSelf *ActorDef[T]) NewRouter(strategy RouterStrategy, size int, effect func(*ActorDef[T], T)) *RouterDef[T] {
+	return RouterNewGenerics(strategy, size, effect)
+}
+
+// SpawnRouter Spawn a Router with parent(this actor) & size routees running the effect(routees use unbounded mailboxes)
+func (actorSelf *ActorDef[T]) SpawnRouter(strategy RouterStrategy, size int, effect func(*ActorDef[T], T)) *RouterDef[T] {
+	router := newRouter(strategy, effect, ActorUnboundedMailboxNewGenerics[T])
+	router.ActorDef = actorSelf.Spawn(router.route)
+	router.Resize(size)
+
+	return router
+}
+
+// RouterNewGenerics New a Router with size routees running the effect(routees use unbounded mailboxes)
+func RouterNewGenerics[T any](strategy RouterStrategy, size int, effect func(*ActorDef[T], T)) *RouterDef[T] {
+	return RouterNewByMailboxGenerics(strategy, size, effect, ActorUnboundedMailboxNewGenerics[T])
+}
+
+// RouterNewByMailboxGenerics New a Router with size routees running the effect, each routee uses the mailbox made by newMailbox
+func RouterNewByMailboxGenerics[T any](strategy RouterStrategy, size int, effect func(*ActorDef[T], T), newMailbox func() ActorMailbox[T]) *RouterDef[T] {
+	router := newRouter(strategy, effect, newMailbox)
+	router.ActorDef = ActorNewGenerics(router.route)
+	router.Resize(size)
+
+	return router
+}
+
+func newRouter[T any](strategy RouterStrategy, effect func(*ActorDef[T], T), newMailbox func() ActorMailbox[T]) *RouterDef[T] {
+	return &RouterDef[T]{
+		strategy:   strategy,
+		effect:     effect,
+		newMailbox: newMailbox,
+		hashKey: func(message T) string {
+			return fmt.Sprintf("%v", message)
+		},
+		deadLetter: defaultRouterDeadLetter[T],
+		ringNode:   map[uint32]*ActorDef[T]{},
+	}
+}
+
+// defaultRouterDeadLetter Log the message failed to be sent to the routee
+func defaultRouterDeadLetter[T any](message T, err error) {
+	log.Printf("router: dead letter %v: %v\n", message, err)
+}
+
+// SetDeadLetter Set the function receiving the messages failed to be sent to the routees(e.g. ErrQueueIsFull, or ErrRouterHasNoRoutees if there is none), they're logged by default
+func (routerSelf *RouterDef[T]) SetDeadLetter(deadLetter func(message T, err error)) *RouterDef[T] {
+	routerSelf.lock.Lock()
+	routerSelf.deadLetter = deadLetter
+	routerSelf.lock.Unlock()
+
+	return routerSelf
+}
+
+// SetHashKey Set the hash key of messages for RouterConsistentHash(fmt "%v" of the message by default)
+func (routerSelf *RouterDef[T]) SetHashKey(hashKey func(T) string) *RouterDef[T] {
+	routerSelf.lock.Lock()
+	routerSelf.hashKey = hashKey
+	routerSelf.lock.Unlock()
+
+	return routerSelf
+}
+
+// GetRoutees Get the routees
+func (routerSelf *RouterDef[T]) GetRoutees() []*ActorDef[T] {
+	routerSelf.lock.RLock()
+	defer routerSelf.lock.RUnlock()
+
+	return append([]*ActorDef[T]{}, routerSelf.routees...)
+}
+
+// Resize Spawn or close(the latest ones) routees to make the pool of the size
+func (routerSelf *RouterDef[T]) Resize(size int) {
+	routerSelf.lock.Lock()
+	defer routerSelf.lock.Unlock()
+
+	routerSelf.removeClosedRoutees()
+	for len(routerSelf.routees) < size {
+		routee, err := routerSelf.spawnByMailbox(newActorName(), routerSelf.effect, routerSelf.newMailbox(), map[string]interface{}{})
+		if err != nil {
+			// The router is closed
+			break
+		}
+		routerSelf.routees = append(routerSelf.routees, routee)
+	}
+	for len(routerSelf.routees) > size && size >= 0 {
+		last := len(routerSelf.routees) - 1
+		routerSelf.routees[last].Close()
+		routerSelf.routees = routerSelf.routees[:last]
+	}
+	routerSelf.buildRing()
+}
+
+// removeClosedRoutees Remove the routees closed by themselves or their supervisor
+func (routerSelf *RouterDef[T]) removeClosedRoutees() bool {
+	routees := routerSelf.routees[:0]
+	for _, routee := range routerSelf.routees {
+		if !routee.IsClosed() {
+			routees = append(routees, routee)
+		}
+	}
+	isChanged := len(routees) != len(routerSelf.routees)
+	for i := len(routees); i < len(routerSelf.routees); i++ {
+		routerSelf.routees[i] = nil
+	}
+	routerSelf.routees = routees
+	return isChanged
+}
+
+func routerHash(key string) uint32 {
+	hash := fnv.New32a()
+	hash.Write([]byte(key))
+	return hash.Sum32()
+}
+
+func (routerSelf *RouterDef[T]) buildRing() {
+	if routerSelf.strategy != RouterConsistentHash {
+		return
+	}
+
+	routerSelf.ring = make([]uint32, 0, len(routerSelf.routees)*routerVirtualNodes)
+	routerSelf.ringNode = make(map[uint32]*ActorDef[T], len(routerSelf.routees)*routerVirtualNodes)
+	for _, routee := range routerSelf.routees {
+		for i := 0; i < routerVirtualNodes; i++ {
+			hash := routerHash(routee.GetPath() + "#" + strconv.Itoa(i))
+			routerSelf.ring = append(routerSelf.ring, hash)
+			routerSelf.ringNode[hash] = routee
+		}
+	}
+	sort.Slice(routerSelf.ring, func(i, j int) bool {
+		return routerSelf.ring[i] < routerSelf.ring[j]
+	})
+}
+
+// getRouteeLoad The pending messages(+1 if it's receiving one)
+func getRouteeLoad[T any](routee *ActorDef[T]) int {
+	load := routee.mailbox.Count()
+	if routee.isReceiving.Get() {
+		load++
+	}
+	return load
+}
+
+// route Forward the message to the routees(in the goroutine of the router),
+// the routees are chosen under the lock but sent outside it, thus a blocking routee doesn't block Resize/GetRoutees
+func (routerSelf *RouterDef[T]) route(_ *ActorDef[T], message T) {
+	targets, deadLetter := routerSelf.selectRoutees(message)
+	if len(targets) == 0 {
+		deadLetter(message, ErrRouterHasNoRoutees)
+		return
+	}
+	for _, routee := range targets {
+		if err := routee.Send(message); err != nil {
+			deadLetter(message, err)
+		}
+	}
+}
+
+// selectRoutees Choose the routees of the message by the strategy
+func (routerSelf *RouterDef[T]) selectRoutees(message T) ([]*ActorDef[T], func(T, error)) {
+	routerSelf.lock.Lock()
+	defer routerSelf.lock.Unlock()
+	if routerSelf.removeClosedRoutees() {
+		routerSelf.buildRing()
+	}
+
+	routees := routerSelf.routees
+	deadLetter := routerSelf.deadLetter
+	if len(routees) == 0 {
+		return nil, deadLetter
+	}
+
+	switch routerSelf.strategy {
+	case RouterBroadcast:
+		return append([]*ActorDef[T]{}, routees...), deadLetter
+	case RouterRandom:
+		return []*ActorDef[T]{routees[rand.Intn(len(routees))]}, deadLetter
+	case RouterConsistentHash:
+		hash := routerHash(routerSelf.hashKey(message))
+		i := sort.Search(len(routerSelf.ring), func(i int) bool {
+			return routerSelf.ring[i] >= hash
+		})
+		if i == len(routerSelf.ring) {
+			i = 0
+		}
+		return []*ActorDef[T]{routerSelf.ringNode[routerSelf.ring[i]]}, deadLetter
+	case RouterSmallestMailbox:
+		smallest := routees[0]
+		smallestCount := getRouteeLoad(smallest)
+		for _, routee := range routees[1:] {
+			count := getRouteeLoad(routee)
+			if count < smallestCount {
+				smallest = routee
+				smallestCount = count
+			}
+		}
+		return []*ActorDef[T]{smallest}, deadLetter
+	default:
+		routee := routees[routerSelf.roundRobinN%uint64(len(routees))]
+		routerSelf.roundRobinN++
+		return []*ActorDef[T]{routee}, deadLetter
+	}
+}
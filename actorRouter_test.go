@@ -0,0 +1,221 @@
+package fpgo
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// collectRoutees New an effect recording "<routee path>:<message>"
+func collectRoutees() (func(*ActorDef[string], string), func() map[string][]string) {
+	var lock sync.Mutex
+	received := map[string][]string{}
+	return func(self *ActorDef[string], input string) {
+			lock.Lock()
+			received[self.GetPath()] = append(received[self.GetPath()], input)
+			lock.Unlock()
+		}, func() map[string][]string {
+			lock.Lock()
+			defer lock.Unlock()
+			result := map[string][]string{}
+			for k, v := range received {
+				result[k] = append([]string{}, v...)
+			}
+			return result
+		}
+}
+
+func countReceived(received map[string][]string) int {
+	count := 0
+	for _, messages := range received {
+		count += len(messages)
+	}
+	return count
+}
+
+func TestRouterRoundRobinAndBroadcast(t *testing.T) {
+	effect, getReceived := collectRoutees()
+	router := RouterNewGenerics(RouterRoundRobin, 3, effect)
+	routees := router.GetRoutees()
+	assert.Equal(t, 3, len(routees))
+	for _, routee := range routees {
+		assert.Equal(t, router.ActorDef, routee.GetParent())
+	}
+	for _, message := range []string{"a", "b", "c", "d", "e", "f"} {
+		router.Send(message)
+	}
+	assert.Eventually(t, func() bool {
+		return countReceived(getReceived()) == 6
+	}, time.Second, time.Millisecond)
+	received := getReceived()
+	assert.Equal(t, []string{"a", "d"}, received[routees[0].GetPath()])
+	assert.Equal(t, []string{"b", "e"}, received[routees[1].GetPath()])
+	assert.Equal(t, []string{"c", "f"}, received[routees[2].GetPath()])
+
+	// Resize
+	router.Resize(1)
+	assert.Equal(t, 1, len(router.GetRoutees()))
+	assert.True(t, routees[2].IsClosed())
+	router.Send("g")
+	router.Send("h")
+	assert.Eventually(t, func() bool {
+		return countReceived(getReceived()) == 8
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, []string{"a", "d", "g", "h"}, getReceived()[routees[0].GetPath()])
+	router.Shutdown(context.Background())
+
+	// Broadcast
+	effect, getReceived = collectRoutees()
+	root := ActorNewGenerics(func(self *ActorDef[string], input string) {})
+	broadcast := root.SpawnRouter(RouterBroadcast, 2, effect)
+	assert.Equal(t, root, broadcast.GetParent())
+	broadcast.Resize(3)
+	broadcast.Send("all")
+	assert.Eventually(t, func() bool {
+		return countReceived(getReceived()) == 3
+	}, time.Second, time.Millisecond)
+	for _, messages := range getReceived() {
+		assert.Equal(t, []string{"all"}, messages)
+	}
+
+	// Shutdown recursively
+	root.Shutdown(context.Background())
+	for _, routee := range broadcast.GetRoutees() {
+		assert.True(t, routee.IsClosed())
+	}
+}
+
+func TestRouterConsistentHashAndRandom(t *testing.T) {
+	effect, getReceived := collectRoutees()
+	router := RouterNewGenerics(RouterConsistentHash, 4, effect).SetHashKey(func(message string) string {
+		return strings.Split(message, ":")[0]
+	})
+	keys := []string{"user1", "user2", "user3", "user4", "user5", "user6"}
+	for i := 0; i < 3; i++ {
+		for _, key := range keys {
+			router.Send(key + ":" + string(rune('a'+i)))
+		}
+	}
+	assert.Eventually(t, func() bool {
+		return countReceived(getReceived()) == 18
+	}, time.Second, time.Millisecond)
+	// The same key goes to the same routee
+	for _, messages := range getReceived() {
+		sort.Strings(messages)
+		for i := 0; i < len(messages); i += 3 {
+			key := strings.Split(messages[i], ":")[0]
+			assert.Equal(t, []string{key + ":a", key + ":b", key + ":c"}, messages[i:i+3])
+		}
+	}
+	router.Shutdown(context.Background())
+
+	effect, getReceived = collectRoutees()
+	router = RouterNewGenerics(RouterRandom, 3, effect)
+	for i := 0; i < 30; i++ {
+		router.Send("random")
+	}
+	assert.Eventually(t, func() bool {
+		return countReceived(getReceived()) == 30
+	}, time.Second, time.Millisecond)
+	router.Shutdown(context.Background())
+}
+
+func TestRouterSmallestMailbox(t *testing.T) {
+	block := make(chan bool)
+	effect, getReceived := collectRoutees()
+	router := RouterNewGenerics(RouterSmallestMailbox, 2, func(self *ActorDef[string], input string) {
+		if input == "block" {
+			<-block
+		}
+		effect(self, input)
+	})
+	routees := router.GetRoutees()
+	// The 1st routee is blocked with 2 pending messages
+	routees[0].Send("block")
+	routees[0].Send("x")
+	routees[0].Send("y")
+	assert.Eventually(t, func() bool {
+		return getRouteeLoad(routees[0]) == 3
+	}, time.Second, time.Millisecond)
+	router.Send("a")
+	router.Send("b")
+	router.Send("c")
+	assert.Eventually(t, func() bool {
+		return len(getReceived()[routees[1].GetPath()]) == 3
+	}, time.Second, time.Millisecond)
+	close(block)
+	assert.Eventually(t, func() bool {
+		return countReceived(getReceived()) == 6
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, []string{"block", "x", "y"}, getReceived()[routees[0].GetPath()])
+
+	// Closed routees are removed
+	routees[0].Close()
+	assert.Eventually(t, routees[0].IsClosed, time.Second, time.Millisecond)
+	router.Send("d")
+	assert.Eventually(t, func() bool {
+		return len(getReceived()[routees[1].GetPath()]) == 4
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, 1, len(router.GetRoutees()))
+	router.Shutdown(context.Background())
+}
+
+func TestRouterDeadLetter(t *testing.T) {
+	block := make(chan bool)
+	effect := func(self *ActorDef[string], input string) {
+		<-block
+	}
+
+	// Overflowed messages go to the dead letter
+	var lock sync.Mutex
+	deadLetters := []string{}
+	router := RouterNewByMailboxGenerics(RouterRoundRobin, 1, effect, func() ActorMailbox[string] {
+		return ActorBoundedMailboxNewGenerics[string](1)
+	}).SetDeadLetter(func(message string, err error) {
+		lock.Lock()
+		defer lock.Unlock()
+		assert.Equal(t, ErrQueueIsFull, err)
+		deadLetters = append(deadLetters, message)
+	})
+	routee := router.GetRoutees()[0]
+	router.Send("a")
+	assert.Eventually(t, routee.isReceiving.Get, time.Second, time.Millisecond)
+	router.Send("b")
+	router.Send("c")
+	assert.Eventually(t, func() bool {
+		lock.Lock()
+		defer lock.Unlock()
+		return len(deadLetters) == 1 && deadLetters[0] == "c"
+	}, time.Second, time.Millisecond)
+
+	// A blocking routee doesn't block the router itself
+	blockingRouter := RouterNewByMailboxGenerics(RouterRoundRobin, 1, effect, func() ActorMailbox[string] {
+		return ActorChannelMailboxNewGenerics(make(chan string))
+	})
+	blockingRouter.Send("a")
+	blockingRouter.Send("b")
+	assert.Eventually(t, func() bool {
+		return getRouteeLoad(blockingRouter.GetRoutees()[0]) == 1
+	}, time.Second, time.Millisecond)
+	blockingRouter.Resize(2)
+	assert.Equal(t, 2, len(blockingRouter.GetRoutees()))
+
+	// No routees
+	deadLetterErrs := make(chan error, 1)
+	emptyRouter := RouterNewGenerics(RouterRoundRobin, 1, effect).SetDeadLetter(func(message string, err error) {
+		deadLetterErrs <- err
+	})
+	emptyRouter.Resize(0)
+	emptyRouter.Send("a")
+	assert.Equal(t, ErrRouterHasNoRoutees, <-deadLetterErrs)
+
+	close(block)
+	router.Shutdown(context.Background())
+	blockingRouter.Shutdown(context.Background())
+	emptyRouter.Shutdown(context.Background())
+}
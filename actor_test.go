@@ -1,6 +1,10 @@
 package fpgo
 
 import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
 	"testing"
 	"time"
 
@@ -131,3 +135,261 @@ func TestActorAsk(t *testing.T) {
 	assert.Equal(t, expectedInt, actual)
 	assert.Equal(t, ErrActorAskTimeout, err)
 }
+
+func TestActorSupervision(t *testing.T) {
+	var lock sync.Mutex
+	lifecycles := []ActorLifecycleMessage[interface{}]{}
+	newSupervisor := func(strategy SupervisorStrategy) *ActorDef[interface{}] {
+		return Actor.New(func(self *ActorDef[interface{}], input interface{}) {}).
+			SetSupervisorStrategy(strategy).
+			SetLifecycleEffect(func(self *ActorDef[interface{}], message ActorLifecycleMessage[interface{}]) {
+				lock.Lock()
+				lifecycles = append(lifecycles, message)
+				lock.Unlock()
+			})
+	}
+	getLifecycles := func() []ActorLifecycleMessage[interface{}] {
+		lock.Lock()
+		defer lock.Unlock()
+		return append([]ActorLifecycleMessage[interface{}]{}, lifecycles...)
+	}
+	// Children count the messages in the context, panic on "panic" & report the count on a chan
+	newChild := func(parent *ActorDef[interface{}], counts chan int) *ActorDef[interface{}] {
+		child := parent.Spawn(func(self *ActorDef[interface{}], input interface{}) {
+			if input == "panic" {
+				panic("child panic")
+			}
+			count, _ := self.context["count"].(int)
+			self.context["count"] = count + 1
+			counts <- count + 1
+		})
+		// Make sure the IDs are in order
+		time.Sleep(time.Millisecond)
+		return child
+	}
+
+	// OneForOne: restart the failed one only, stop it when MaxRestarts exceeded
+	supervisor := newSupervisor(SupervisorStrategy{Type: SupervisorOneForOne, MaxRestarts: 1, Window: time.Second})
+	counts1 := make(chan int, 10)
+	counts2 := make(chan int, 10)
+	child1 := newChild(supervisor, counts1)
+	child2 := newChild(supervisor, counts2)
+	child1.Send(1)
+	child2.Send(1)
+	assert.Equal(t, 1, <-counts1)
+	assert.Equal(t, 1, <-counts2)
+	child1.Send("panic")
+	child1.Send(1)
+	child2.Send(1)
+	assert.Equal(t, 1, <-counts1)
+	assert.Equal(t, 2, <-counts2)
+	child1.Send("panic")
+	assert.Eventually(t, func() bool {
+		return len(getLifecycles()) == 3
+	}, time.Second, time.Millisecond)
+	actualLifecycles := getLifecycles()
+	assert.Equal(t, ActorLifecycleChildFailed, actualLifecycles[0].Type)
+	assert.Equal(t, SupervisorRestart, actualLifecycles[0].Directive)
+	assert.Equal(t, "child panic", actualLifecycles[0].Err.(*PanicError).Value)
+	assert.Equal(t, child1, actualLifecycles[0].Child)
+	assert.Equal(t, SupervisorStop, actualLifecycles[1].Directive)
+	assert.Equal(t, ActorLifecycleChildTerminated, actualLifecycles[2].Type)
+	assert.True(t, child1.IsClosed())
+	assert.False(t, child2.IsClosed())
+	assert.Nil(t, supervisor.GetChild(child1.GetID()))
+
+	// OneForAll: restart all children
+	lifecycles = nil
+	supervisor = newSupervisor(SupervisorStrategy{Type: SupervisorOneForAll, MaxRestarts: -1})
+	child1 = newChild(supervisor, counts1)
+	child2 = newChild(supervisor, counts2)
+	child2.Send(1)
+	assert.Equal(t, 1, <-counts2)
+	child1.Send("panic")
+	assert.Eventually(t, func() bool {
+		return len(getLifecycles()) == 1
+	}, time.Second, time.Millisecond)
+	child2.Send(1)
+	assert.Equal(t, 1, <-counts2)
+
+	// RestForOne: restart the failed one & the later ones, stop them all when MaxRestarts exceeded
+	lifecycles = nil
+	supervisor = newSupervisor(SupervisorStrategy{Type: SupervisorRestForOne, MaxRestarts: 0})
+	counts3 := make(chan int, 10)
+	child1 = newChild(supervisor, counts1)
+	child2 = newChild(supervisor, counts2)
+	child3 := newChild(supervisor, counts3)
+	child2.Send("panic")
+	assert.Eventually(t, func() bool {
+		return len(getLifecycles()) == 3
+	}, time.Second, time.Millisecond)
+	assert.False(t, child1.IsClosed())
+	assert.True(t, child2.IsClosed())
+	assert.True(t, child3.IsClosed())
+
+	// No parent: restart by DefaultSupervisorStrategy
+	counts := make(chan int, 10)
+	root := Actor.New(func(self *ActorDef[interface{}], input interface{}) {
+		if input == "panic" {
+			panic("root panic")
+		}
+		counts <- 1
+	})
+	root.Send("panic")
+	root.Send(1)
+	assert.Equal(t, 1, <-counts)
+}
+
+func TestActorAskGenerics(t *testing.T) {
+	var result string
+	var err error
+
+	// Typed Actor of AskDefs
+	replied := make(chan bool, 10)
+	actorAsk := ActorNewGenerics(func(self *ActorDef[*AskDef[int, string]], ask *AskDef[int, string]) {
+		// NOTE If negative, replying late for testing the discarding
+		if ask.Message < 0 {
+			time.Sleep(30 * time.Millisecond)
+		}
+		ask.Reply(strconv.Itoa(ask.Message * 10))
+		replied <- true
+	})
+	result, err = ActorAskGenerics[int, string](context.Background(), actorAsk, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "10", result)
+	<-replied
+
+	// Timeout: the late reply doesn't block the Actor
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	result, err = ActorAskGenerics[int, string](ctx, actorAsk, -1)
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Equal(t, "", result)
+	<-replied
+	result, err = ActorAskGenerics[int, string](context.Background(), actorAsk, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "20", result)
+	<-replied
+
+	// Canceled
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	_, err = ActorAskGenerics[int, string](ctx, actorAsk, 3)
+	assert.Equal(t, context.Canceled, err)
+
+	// Closed
+	actorAsk.Close()
+	_, err = ActorAskGenerics[int, string](context.Background(), actorAsk, 4)
+	assert.Equal(t, ErrQueueIsClosed, err)
+
+	// Actor of any message type
+	type message struct {
+		text string
+		ask  *AskDef[string, error]
+	}
+	errExpected := errors.New("typed error")
+	actorTyped := ActorNewGenerics(func(self *ActorDef[message], input message) {
+		if input.ask != nil {
+			input.ask.Reply(errExpected)
+		}
+	})
+	var errResult error
+	errResult, err = ActorAskByGenerics[string, error, message](context.Background(), actorTyped, "hello", func(ask *AskDef[string, error]) message {
+		return message{text: ask.Message, ask: ask}
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, errExpected, errResult)
+
+	// Late replies of AskOnceWithTimeout are discarded too
+	actorRoot := Actor.New(func(self *ActorDef[interface{}], input interface{}) {
+		ask := input.(*AskDef[interface{}, int])
+		time.Sleep(20 * time.Millisecond)
+		ask.Reply(1)
+		replied <- true
+	})
+	_, err = AskNewGenerics[interface{}, int](1).AskOnceWithTimeout(actorRoot, 5*time.Millisecond)
+	assert.Equal(t, ErrActorAskTimeout, err)
+	<-replied
+}
+
+func TestActorBecomeAndStash(t *testing.T) {
+	received := make(chan string, 10)
+	var connected func(self *ActorDef[string], input string)
+	disconnected := func(self *ActorDef[string], input string) {
+		switch input {
+		case "connect":
+			self.BecomeStacked(connected)
+			self.UnstashAll()
+		default:
+			// Not ready yet
+			self.Stash()
+		}
+	}
+	connected = func(self *ActorDef[string], input string) {
+		switch input {
+		case "disconnect":
+			self.Unbecome()
+		case "panic":
+			panic(input)
+		case "close":
+			self.Become(func(self *ActorDef[string], input string) {
+				received <- "closed:" + input
+			})
+		default:
+			received <- "sent:" + input
+		}
+	}
+	actor := ActorNewByMailboxGenerics(disconnected, ActorUnboundedMailboxNewGenerics[string](), map[string]interface{}{})
+
+	actor.Send("a")
+	actor.Send("b")
+	actor.Send("connect")
+	actor.Send("c")
+	assert.Equal(t, "sent:a", <-received)
+	assert.Equal(t, "sent:b", <-received)
+	assert.Equal(t, "sent:c", <-received)
+
+	// Unbecome: stash again
+	actor.Send("disconnect")
+	actor.Send("d")
+	actor.Send("connect")
+	assert.Equal(t, "sent:d", <-received)
+
+	// Restarted: back to the initial behavior
+	actor.Send("panic")
+	actor.Send("e")
+	actor.Send("connect")
+	assert.Equal(t, "sent:e", <-received)
+
+	// Become: replace the current one
+	actor.Send("close")
+	actor.Send("f")
+	assert.Equal(t, "closed:f", <-received)
+}
+
+func TestActorSendAfterAndEvery(t *testing.T) {
+	received := make(chan string, 100)
+	actor := ActorNewGenerics(func(self *ActorDef[string], input string) {
+		received <- input
+	})
+
+	after := actor.SendAfter("after", 10*time.Millisecond)
+	actor.SendAfter("cancelled", 10*time.Millisecond).Cancel()
+	assert.Equal(t, "after", <-received)
+	assert.Eventually(t, after.IsDone, time.Second, time.Millisecond)
+	assert.False(t, after.Cancel())
+
+	every := actor.SendEvery("every", 5*time.Millisecond)
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, "every", <-received)
+	}
+	assert.True(t, every.Cancel())
+
+	// Cleaned up when closed
+	every = actor.SendEvery("every", 5*time.Millisecond)
+	actor.SendAfter("pending", time.Second)
+	actor.Shutdown(context.Background())
+	assert.True(t, every.IsCancelled())
+	assert.Equal(t, 0, actor.timers.count())
+	assert.True(t, actor.SendAfter("closed", 0).IsCancelled())
+}
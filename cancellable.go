@@ -0,0 +1,206 @@
+package fpgo
+
+import (
+	"sync"
+	"time"
+)
+
+// CancellableDef The token of a scheduled(delayed or periodic) work, it could be cancelled before being done
+type CancellableDef struct {
+	lock        sync.Mutex
+	isCancelled bool
+	isDone      bool
+	timer       *time.Timer
+	group       *cancellableGroup
+	token       interface{}
+	// dequeue Remove the queued work(false if it has been taken out to run), the queued work is done when it's taken out to run
+	dequeue func() bool
+}
+
+// Cancel Cancel the scheduled work, returns false if it has been done or cancelled
+func (cancellableSelf *CancellableDef) Cancel() bool {
+	cancellableSelf.lock.Lock()
+	if cancellableSelf.isCancelled || cancellableSelf.isDone {
+		cancellableSelf.lock.Unlock()
+		return false
+	}
+	if cancellableSelf.dequeue != nil && !cancellableSelf.dequeue() {
+		cancellableSelf.isDone = true
+		cancellableSelf.lock.Unlock()
+		return false
+	}
+	cancellableSelf.isCancelled = true
+	if cancellableSelf.timer != nil {
+		cancellableSelf.timer.Stop()
+	}
+	cancellableSelf.lock.Unlock()
+
+	if cancellableSelf.group != nil {
+		cancellableSelf.group.remove(cancellableSelf)
+	}
+	return true
+}
+
+// IsCancelled Is it cancelled
+func (cancellableSelf *CancellableDef) IsCancelled() bool {
+	cancellableSelf.lock.Lock()
+	defer cancellableSelf.lock.Unlock()
+
+	return cancellableSelf.isCancelled
+}
+
+// IsDone Is the scheduled work done(periodic ones are done only if the target has stopped accepting them)
+func (cancellableSelf *CancellableDef) IsDone() bool {
+	cancellableSelf.lock.Lock()
+	defer cancellableSelf.lock.Unlock()
+
+	return cancellableSelf.isDone
+}
+
+func (cancellableSelf *CancellableDef) fire(interval time.Duration, work func(*CancellableDef) bool) {
+	cancellableSelf.lock.Lock()
+	if cancellableSelf.isCancelled {
+		cancellableSelf.lock.Unlock()
+		return
+	}
+	cancellableSelf.lock.Unlock()
+
+	// Keep scheduling until the target stops accepting
+	isAccepted := work(cancellableSelf)
+
+	cancellableSelf.lock.Lock()
+	if cancellableSelf.isCancelled {
+		cancellableSelf.lock.Unlock()
+		return
+	}
+	if interval > 0 && isAccepted {
+		cancellableSelf.timer.Reset(interval)
+		cancellableSelf.lock.Unlock()
+		return
+	}
+	// The queued one is still cancellable until it's taken out to run
+	if cancellableSelf.dequeue == nil || !isAccepted {
+		cancellableSelf.isDone = true
+	}
+	cancellableSelf.lock.Unlock()
+
+	if cancellableSelf.group != nil {
+		cancellableSelf.group.remove(cancellableSelf)
+	}
+}
+
+func (cancellableSelf *CancellableDef) markCancelled() {
+	cancellableSelf.lock.Lock()
+	defer cancellableSelf.lock.Unlock()
+
+	if !cancellableSelf.isDone {
+		cancellableSelf.isCancelled = true
+	}
+}
+
+func (cancellableSelf *CancellableDef) markDone() {
+	cancellableSelf.lock.Lock()
+	defer cancellableSelf.lock.Unlock()
+
+	if !cancellableSelf.isCancelled {
+		cancellableSelf.isDone = true
+	}
+}
+
+// newCancelledCancellable New a CancellableDef cancelled already(for the closed targets)
+func newCancelledCancellable() *CancellableDef {
+	return &CancellableDef{isCancelled: true}
+}
+
+// cancellableGroup The pending CancellableDefs of a target(cancelled all when the target is closed)
+type cancellableGroup struct {
+	lock        sync.Mutex
+	isClosed    bool
+	cancellable map[*CancellableDef]bool
+}
+
+func newCancellableGroup() *cancellableGroup {
+	return &cancellableGroup{
+		cancellable: map[*CancellableDef]bool{},
+	}
+}
+
+// schedule Run the work after the delay(and then every interval if interval > 0 & the work returns true)
+func (groupSelf *cancellableGroup) schedule(delay time.Duration, interval time.Duration, work func() bool) *CancellableDef {
+	return groupSelf.scheduleByToken(delay, interval, nil, work)
+}
+
+// scheduleByToken schedule the work tagged by the token(for cancelIf)
+func (groupSelf *cancellableGroup) scheduleByToken(delay time.Duration, interval time.Duration, token interface{}, work func() bool) *CancellableDef {
+	return groupSelf.scheduleCancellable(delay, interval, token, func(*CancellableDef) bool {
+		return work()
+	})
+}
+
+// scheduleCancellable scheduleByToken the work receiving its own CancellableDef(e.g. to make the queued work cancellable)
+func (groupSelf *cancellableGroup) scheduleCancellable(delay time.Duration, interval time.Duration, token interface{}, work func(*CancellableDef) bool) *CancellableDef {
+	cancellable := &CancellableDef{group: groupSelf, token: token}
+
+	groupSelf.lock.Lock()
+	if groupSelf.isClosed {
+		groupSelf.lock.Unlock()
+		return newCancelledCancellable()
+	}
+	groupSelf.cancellable[cancellable] = true
+	groupSelf.lock.Unlock()
+
+	cancellable.lock.Lock()
+	cancellable.timer = time.AfterFunc(delay, func() {
+		cancellable.fire(interval, work)
+	})
+	cancellable.lock.Unlock()
+
+	return cancellable
+}
+
+func (groupSelf *cancellableGroup) remove(cancellable *CancellableDef) {
+	groupSelf.lock.Lock()
+	defer groupSelf.lock.Unlock()
+
+	delete(groupSelf.cancellable, cancellable)
+}
+
+func (groupSelf *cancellableGroup) count() int {
+	groupSelf.lock.Lock()
+	defer groupSelf.lock.Unlock()
+
+	return len(groupSelf.cancellable)
+}
+
+// cancelIf Cancel the pending ones whose tokens are matched, returns the count of the cancelled ones
+func (groupSelf *cancellableGroup) cancelIf(match func(token interface{}) bool) int {
+	groupSelf.lock.Lock()
+	matched := []*CancellableDef{}
+	for cancellable := range groupSelf.cancellable {
+		if match(cancellable.token) {
+			matched = append(matched, cancellable)
+		}
+	}
+	groupSelf.lock.Unlock()
+
+	cancelled := 0
+	for _, cancellable := range matched {
+		if cancellable.Cancel() {
+			cancelled++
+		}
+	}
+	return cancelled
+}
+
+// close Cancel all pending ones & reject the later ones
+func (groupSelf *cancellableGroup) close() {
+	groupSelf.lock.Lock()
+	groupSelf.isClosed = true
+	pending := groupSelf.cancellable
+	groupSelf.cancellable = map[*CancellableDef]bool{}
+	groupSelf.lock.Unlock()
+
+	for cancellable := range pending {
+		cancellable.Cancel()
+	}
+}
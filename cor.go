@@ -1,10 +1,19 @@
 package fpgo
 
 import (
+	"context"
+	"errors"
 	"sync"
 	"sync/atomic"
 )
 
+var (
+	// ErrCorCancelled Cor Is Cancelled
+	ErrCorCancelled = errors.New("cor is cancelled")
+	// ErrCorIsDone Cor Is Done(without replying)
+	ErrCorIsDone = errors.New("cor is done")
+)
+
 // AtomBool Atomic Bool
 type AtomBool struct{ flag int32 }
 
@@ -30,6 +39,9 @@ func (atomBoolSelf *AtomBool) Get() bool {
 type CorOp[T any] struct {
 	cor *CorDef[T]
 	val T
+
+	// replyCh The reply channel of this request only(thus a late reply never matches a newer request)
+	replyCh chan T
 }
 
 // CorDef Cor Coroutine inspired by Python/Ecmascript/Lua
@@ -38,8 +50,13 @@ type CorDef[T any] struct {
 	isClosed  AtomBool
 	closedM   sync.Mutex
 
-	opCh     chan *CorOp[T]
-	resultCh chan T
+	opCh chan *CorOp[T]
+
+	// The terminal states
+	lock   sync.Mutex
+	doneCh chan bool
+	err    error
+	errChs []chan error
 
 	effect func()
 }
@@ -54,12 +71,17 @@ func CorNewGenerics[T any](effect func()) *CorDef[T] {
 	cor := &CorDef[T]{
 		effect:    effect,
 		opCh:      make(chan *CorOp[T], 5),
-		resultCh:  make(chan T, 5),
+		doneCh:    make(chan bool),
 		isStarted: AtomBool{flag: 0},
 	}
 	return cor
 }
 
+// NewGenerator New a Generator of the values yielded by the effect(running in a Cor lazily)
+func (corSelf *CorDef[T]) NewGenerator(effect func(yield func(T))) *GeneratorDef[T] {
+	return GeneratorNewGenerics(effect)
+}
+
 // NewAndStart New a Cor instance and start it immediately
 func (corSelf *CorDef[T]) NewAndStart(effect func()) *CorDef[T] {
 	cor := CorNewGenerics[T](effect)
@@ -67,19 +89,19 @@ func (corSelf *CorDef[T]) NewAndStart(effect func()) *CorDef[T] {
 	return cor
 }
 
-// DoNotation Do Notation by function (inspired by Haskell one)
+// DoNotation Do Notation by function (inspired by Haskell one), the panic inside the effect is rethrown
 func (corSelf *CorDef[T]) DoNotation(effect func(*CorDef[T]) T) T {
 	var result T
 
-	var wg sync.WaitGroup
-	wg.Add(1)
 	var cor *CorDef[T]
 	cor = CorNewGenerics[T](func() {
 		result = effect(cor)
-		wg.Done()
 	})
 	cor.Start()
-	wg.Wait()
+	<-cor.doneCh
+	if panicError, ok := cor.Err().(*PanicError); ok {
+		panic(panicError.Value)
+	}
 
 	return result
 }
@@ -90,7 +112,7 @@ func (corSelf *CorDef[T]) StartWithVal(in T) {
 		return
 	}
 
-	corSelf.receive(nil, in)
+	corSelf.receive(&CorOp[T]{val: in})
 	corSelf.Start()
 }
 
@@ -102,11 +124,23 @@ func (corSelf *CorDef[T]) Start() {
 	corSelf.isStarted.Set(true)
 
 	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				corSelf.terminate(NewPanicError(r))
+				return
+			}
+			corSelf.terminate(nil)
+		}()
+
 		corSelf.effect()
-		corSelf.close()
 	}()
 }
 
+// Cancel Cancel the Cor, the peers waiting on it get ErrCorCancelled(the blocked YieldRef of its effect returns the zero value)
+func (corSelf *CorDef[T]) Cancel() {
+	corSelf.terminate(ErrCorCancelled)
+}
+
 // // Yield Yield back(nil)
 // func (corSelf *CorDef[T]) Yield() T {
 // 	return corSelf.YieldRef(nil)
@@ -115,48 +149,88 @@ func (corSelf *CorDef[T]) Start() {
 // YieldRef Yield a value
 func (corSelf *CorDef[T]) YieldRef(out T) T {
 	var result T
-	if corSelf.IsDone() {
+	op := corSelf.awaitOp()
+	if op == nil {
+		// Cancelled
 		return result
 	}
 
-	var op *CorOp[T]
-	var more bool
+	op.reply(out)
+	result = op.val
+
+	return result
+}
+
+// awaitOp Wait for the next request(nil if it's done or cancelled)
+func (corSelf *CorDef[T]) awaitOp() *CorOp[T] {
+	if corSelf.IsDone() {
+		return nil
+	}
+
 	// fmt.Println(corSelf, "Wait for", "op")
-	op, more = <-corSelf.opCh
+	op, more := <-corSelf.opCh
 	// fmt.Println(corSelf, "Wait for", "op", "done")
+	if !more {
+		return nil
+	}
+	return op
+}
 
-	if more && op != nil && op.cor != nil {
-		cor := op.cor
-		cor.doCloseSafe(func() {
-			cor.resultCh <- out
-		})
+// reply Reply to the requester of the op(if any)
+func (opSelf *CorOp[T]) reply(out T) {
+	if opSelf.replyCh != nil {
+		// Buffered for the only reply, it never blocks even if the requester has given up
+		opSelf.replyCh <- out
 	}
-	result = op.val
+}
 
+// YieldFrom Yield from a given Cor(the zero value if the target is done without replying)
+func (corSelf *CorDef[T]) YieldFrom(target *CorDef[T], in T) T {
+	result, _ := corSelf.YieldFromContext(context.Background(), target, in)
 	return result
 }
 
-// YieldFrom Yield from a given Cor
-func (corSelf *CorDef[T]) YieldFrom(target *CorDef[T], in T) T {
+// YieldFromContext Yield from a given Cor, returns the terminal error of the target(ErrCorIsDone if it's done without error) or ctx.Err() if it doesn't reply in time
+func (corSelf *CorDef[T]) YieldFromContext(ctx context.Context, target *CorDef[T], in T) (T, error) {
 	var result T
 	if corSelf.IsDone() {
-		return result
+		return result, corSelf.getTerminalError()
+	}
+	if err := ctx.Err(); err != nil {
+		return result, err
 	}
 
-	target.receive(corSelf, in)
+	// A reply channel per request: the late replies of the previous timeout ones are never taken
+	replyCh := make(chan T, 1)
+	target.receive(&CorOp[T]{cor: corSelf, val: in, replyCh: replyCh})
 
 	// fmt.Println(corSelf, "Wait for", "result")
-	result, _ = <-corSelf.resultCh
-	// fmt.Println(corSelf, "Wait for", "result", "done")
-
-	return result
+	select {
+	case result = <-replyCh:
+		return result, nil
+	case <-corSelf.doneCh:
+		return result, corSelf.getTerminalError()
+	case <-target.doneCh:
+		// The reply is sent before it's done
+		select {
+		case result = <-replyCh:
+			return result, nil
+		default:
+		}
+		return result, target.getTerminalError()
+	case <-ctx.Done():
+		return result, ctx.Err()
+	}
 }
 
-func (corSelf *CorDef[T]) receive(cor *CorDef[T], in T) {
+func (corSelf *CorDef[T]) receive(op *CorOp[T]) {
 	corSelf.doCloseSafe(func() {
 		if corSelf.opCh != nil {
-			// fmt.Println(corSelf, "Wait for", "receive", cor, in)
-			corSelf.opCh <- &CorOp[T]{cor: cor, val: in}
+			// fmt.Println(corSelf, "Wait for", "receive", op.cor, op.val)
+			select {
+			case corSelf.opCh <- op:
+			case <-corSelf.doneCh:
+			}
 			// fmt.Println(corSelf, "Wait for", "receive", "done")
 		}
 	})
@@ -173,6 +247,9 @@ func (corSelf *CorDef[T]) YieldFromIO(target *MonadIODef[T]) T {
 			result = in
 			wg.Done()
 		},
+		OnError: func(_ error) {
+			wg.Done()
+		},
 	})
 	wg.Wait()
 
@@ -184,18 +261,76 @@ func (corSelf *CorDef[T]) IsDone() bool {
 	return corSelf.isClosed.Get()
 }
 
+// IsDoneWithError Is the Cor done, with its terminal error(PanicError, ErrCorCancelled or nil if it's finished normally)
+func (corSelf *CorDef[T]) IsDoneWithError() (bool, error) {
+	corSelf.lock.Lock()
+	defer corSelf.lock.Unlock()
+
+	return corSelf.isClosed.Get(), corSelf.err
+}
+
+// Err Get the terminal error(PanicError, ErrCorCancelled or nil if it's finished normally or not done)
+func (corSelf *CorDef[T]) Err() error {
+	_, err := corSelf.IsDoneWithError()
+	return err
+}
+
+// ErrorChannel Get a channel receiving the terminal error(if any) & then closed when the Cor is done
+func (corSelf *CorDef[T]) ErrorChannel() <-chan error {
+	ch := make(chan error, 1)
+
+	corSelf.lock.Lock()
+	defer corSelf.lock.Unlock()
+	if !corSelf.isClosed.Get() {
+		corSelf.errChs = append(corSelf.errChs, ch)
+		return ch
+	}
+	if corSelf.err != nil {
+		ch <- corSelf.err
+	}
+	close(ch)
+	return ch
+}
+
 // IsStarted Is the Cor started
 func (corSelf *CorDef[T]) IsStarted() bool {
 	return corSelf.isStarted.Get()
 }
 
+func (corSelf *CorDef[T]) getTerminalError() error {
+	if err := corSelf.Err(); err != nil {
+		return err
+	}
+	return ErrCorIsDone
+}
+
+// terminate Mark it done with the terminal error & notify the waiting ones
+func (corSelf *CorDef[T]) terminate(err error) {
+	corSelf.lock.Lock()
+	if corSelf.isClosed.Get() {
+		corSelf.lock.Unlock()
+		return
+	}
+	corSelf.err = err
+	corSelf.isClosed.Set(true)
+	close(corSelf.doneCh)
+	errChs := corSelf.errChs
+	corSelf.errChs = nil
+	corSelf.lock.Unlock()
+
+	for _, ch := range errChs {
+		if err != nil {
+			ch <- err
+		}
+		close(ch)
+	}
+	corSelf.close()
+}
+
 func (corSelf *CorDef[T]) close() {
 	corSelf.isClosed.Set(true)
 
 	corSelf.closedM.Lock()
-	if corSelf.resultCh != nil {
-		close(corSelf.resultCh)
-	}
 	if corSelf.opCh != nil {
 		close(corSelf.opCh)
 	}
@@ -203,12 +338,13 @@ func (corSelf *CorDef[T]) close() {
 }
 
 func (corSelf *CorDef[T]) doCloseSafe(fn func()) {
+	corSelf.closedM.Lock()
+	defer corSelf.closedM.Unlock()
+	// Check it again with the lock held, the channels might be closed meanwhile
 	if corSelf.IsDone() {
 		return
 	}
-	corSelf.closedM.Lock()
 	fn()
-	corSelf.closedM.Unlock()
 }
 
 // Cor Cor utils instance
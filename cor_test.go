@@ -1,9 +1,11 @@
 package fpgo
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -122,3 +124,86 @@ func TestCorDoNotation(t *testing.T) {
 
 	assert.Equal(t, expectedInt, (actual))
 }
+
+func TestCorCancelAndError(t *testing.T) {
+	var err error
+	var isDone bool
+
+	// The peer hangs
+	silent := CorNewGenerics[int](func() {
+		time.Sleep(time.Second)
+	})
+	silent.Start()
+	caller := CorNewGenerics[int](func() {})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = caller.YieldFromContext(ctx, silent, 1)
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	// The late reply of the timeout request doesn't match the next one
+	gate := make(chan bool)
+	var late *CorDef[int]
+	late = CorNewGenerics[int](func() {
+		<-gate
+		late.YieldRef(1)
+		late.YieldRef(2)
+	})
+	late.Start()
+	lateCtx, lateCancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer lateCancel()
+	_, err = caller.YieldFromContext(lateCtx, late, 0)
+	assert.Equal(t, context.DeadlineExceeded, err)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		close(gate)
+	}()
+	lateResult, err := caller.YieldFromContext(context.Background(), late, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, lateResult)
+
+	// Cancelled while waiting for the peer
+	errCh := silent.ErrorChannel()
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		silent.Cancel()
+	}()
+	_, err = caller.YieldFromContext(context.Background(), silent, 1)
+	assert.Equal(t, ErrCorCancelled, err)
+	assert.Equal(t, ErrCorCancelled, <-errCh)
+	_, ok := <-errCh
+	assert.False(t, ok)
+	isDone, err = silent.IsDoneWithError()
+	assert.True(t, isDone)
+	assert.Equal(t, ErrCorCancelled, err)
+
+	// Panic
+	var panicking *CorDef[int]
+	panicking = CorNewGenerics[int](func() {
+		panicking.YieldRef(1)
+		panic("oops")
+	})
+	panicking.Start()
+	errCh = panicking.ErrorChannel()
+	result, err := caller.YieldFromContext(context.Background(), panicking, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result)
+	_, err = caller.YieldFromContext(context.Background(), panicking, 0)
+	assert.Equal(t, &PanicError{Value: "oops"}, err)
+	assert.Equal(t, &PanicError{Value: "oops"}, <-errCh)
+	assert.Equal(t, &PanicError{Value: "oops"}, <-panicking.ErrorChannel())
+	assert.Equal(t, &PanicError{Value: "oops"}, panicking.Err())
+
+	// Finished without replying
+	finished := CorNewGenerics[int](func() {})
+	finished.Start()
+	_, err = caller.YieldFromContext(context.Background(), finished, 0)
+	assert.Equal(t, ErrCorIsDone, err)
+	assert.NoError(t, finished.Err())
+
+	// DoNotation rethrows the panic
+	assert.PanicsWithValue(t, "oops", func() {
+		Cor.DoNotation(func(self *CorDef[interface{}]) interface{} {
+			panic("oops")
+		})
+	})
+}
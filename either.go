@@ -0,0 +1,167 @@
+package fpgo
+
+import "fmt"
+
+// EitherDef[L, R] Either inspired by Haskell/Scala, it's either a Left or a Right(right-biased: Map/FlatMap work on the Right)
+type EitherDef[L any, R any] struct {
+	left    L
+	right   R
+	isRight bool
+}
+
+// EitherLeft New a Left Either
+func EitherLeft[L any, R any](left L) EitherDef[L, R] {
+	return EitherDef[L, R]{left: left}
+}
+
+// EitherRight New a Right Either
+func EitherRight[L any, R any](right R) EitherDef[L, R] {
+	return EitherDef[L, R]{right: right, isRight: true}
+}
+
+// EitherFromMaybe New a Right Either by the present Maybe, or a Left of left
+func EitherFromMaybe[L any, R any](maybe MaybeDef[R], left L) EitherDef[L, R] {
+	if !maybe.IsPresent() {
+		return EitherLeft[L, R](left)
+	}
+	return EitherRight[L](maybe.Unwrap())
+}
+
+// EitherToResult Right to an Ok, Left to an Err of the error
+func EitherToResult[R any](eitherSelf EitherDef[error, R]) ResultDef[R] {
+	if eitherSelf.IsLeft() {
+		return ResultErr[R](eitherSelf.left)
+	}
+	return ResultOk(eitherSelf.right)
+}
+
+// EitherMap Map the Right to an another type by function(the Left is passed through)
+func EitherMap[L any, R any, X any](eitherSelf EitherDef[L, R], fn func(R) X) EitherDef[L, X] {
+	if eitherSelf.IsLeft() {
+		return EitherLeft[L, X](eitherSelf.left)
+	}
+	return EitherRight[L](fn(eitherSelf.right))
+}
+
+// EitherFlatMap FlatMap the Right to an another Either by function(the Left is passed through)
+func EitherFlatMap[L any, R any, X any](eitherSelf EitherDef[L, R], fn func(R) EitherDef[L, X]) EitherDef[L, X] {
+	if eitherSelf.IsLeft() {
+		return EitherLeft[L, X](eitherSelf.left)
+	}
+	return fn(eitherSelf.right)
+}
+
+// EitherFold Fold the Either into a value by the function of its side
+func EitherFold[L any, R any, X any](eitherSelf EitherDef[L, R], onLeft func(L) X, onRight func(R) X) X {
+	if eitherSelf.IsLeft() {
+		return onLeft(eitherSelf.left)
+	}
+	return onRight(eitherSelf.right)
+}
+
+// EitherSequence Collect the Rights of the Eithers, or the first Left
+func EitherSequence[L any, R any](eithers ...EitherDef[L, R]) EitherDef[L, []R] {
+	rights := make([]R, 0, len(eithers))
+	for _, either := range eithers {
+		if either.IsLeft() {
+			return EitherLeft[L, []R](either.left)
+		}
+		rights = append(rights, either.right)
+	}
+	return EitherRight[L](rights)
+}
+
+// EitherTraverse Map the values to Eithers by function & collect the Rights, or stop at the first Left
+func EitherTraverse[T any, L any, R any](fn func(T) EitherDef[L, R], values ...T) EitherDef[L, []R] {
+	rights := make([]R, 0, len(values))
+	for _, value := range values {
+		either := fn(value)
+		if either.IsLeft() {
+			return EitherLeft[L, []R](either.left)
+		}
+		rights = append(rights, either.right)
+	}
+	return EitherRight[L](rights)
+}
+
+// IsLeft Is it a Left
+func (eitherSelf EitherDef[L, R]) IsLeft() bool {
+	return !eitherSelf.isRight
+}
+
+// IsRight Is it a Right
+func (eitherSelf EitherDef[L, R]) IsRight() bool {
+	return eitherSelf.isRight
+}
+
+// Left Get the Left(false if it's a Right)
+func (eitherSelf EitherDef[L, R]) Left() (L, bool) {
+	return eitherSelf.left, eitherSelf.IsLeft()
+}
+
+// Right Get the Right(false if it's a Left)
+func (eitherSelf EitherDef[L, R]) Right() (R, bool) {
+	return eitherSelf.right, eitherSelf.IsRight()
+}
+
+// Unwrap Get the Right, it panics if it's a Left(with the Left itself if it's an error)
+func (eitherSelf EitherDef[L, R]) Unwrap() R {
+	if eitherSelf.IsLeft() {
+		var left interface{} = eitherSelf.left
+		if err, ok := left.(error); ok {
+			panic(err)
+		}
+		panic(fmt.Errorf("either is left: %v", left))
+	}
+	return eitherSelf.right
+}
+
+// UnwrapOr Get the Right, or the fallback value if it's a Left
+func (eitherSelf EitherDef[L, R]) UnwrapOr(or R) R {
+	if eitherSelf.IsLeft() {
+		return or
+	}
+	return eitherSelf.right
+}
+
+// Map Map the Right by function(the Left is passed through)
+func (eitherSelf EitherDef[L, R]) Map(fn func(R) R) EitherDef[L, R] {
+	return EitherMap(eitherSelf, fn)
+}
+
+// FlatMap FlatMap the Right to an another Either by function(the Left is passed through)
+func (eitherSelf EitherDef[L, R]) FlatMap(fn func(R) EitherDef[L, R]) EitherDef[L, R] {
+	return EitherFlatMap(eitherSelf, fn)
+}
+
+// MapErr Map the Left by function(the Right is passed through)
+func (eitherSelf EitherDef[L, R]) MapErr(fn func(L) L) EitherDef[L, R] {
+	if eitherSelf.IsRight() {
+		return eitherSelf
+	}
+	return EitherLeft[L, R](fn(eitherSelf.left))
+}
+
+// OrElse Recover the Left to an another Either by function(the Right is passed through)
+func (eitherSelf EitherDef[L, R]) OrElse(fn func(L) EitherDef[L, R]) EitherDef[L, R] {
+	if eitherSelf.IsRight() {
+		return eitherSelf
+	}
+	return fn(eitherSelf.left)
+}
+
+// Swap Swap the sides
+func (eitherSelf EitherDef[L, R]) Swap() EitherDef[R, L] {
+	if eitherSelf.IsLeft() {
+		return EitherRight[R](eitherSelf.left)
+	}
+	return EitherLeft[R, L](eitherSelf.right)
+}
+
+// ToMaybe Right to a Maybe of the value, Left to an absent Maybe
+func (eitherSelf EitherDef[L, R]) ToMaybe() MaybeDef[R] {
+	if eitherSelf.IsLeft() {
+		return NoneOf[R]()
+	}
+	return JustGenerics(eitherSelf.right)
+}
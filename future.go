@@ -0,0 +1,339 @@
+package fpgo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var (
+	// ErrFutureIsNil The Future to flatten is nil
+	ErrFutureIsNil = errors.New("future is nil")
+)
+
+// FutureAggregateError The errors of all the rejected Futures of FutureAny
+type FutureAggregateError struct {
+	Errors []error
+}
+
+// Error The error message of the rejected Futures
+func (aggregateErrorSelf *FutureAggregateError) Error() string {
+	return fmt.Sprintf("all futures are rejected: %v", aggregateErrorSelf.Errors)
+}
+
+// FutureSettled[T] The outcome of a settled Future(Err is nil if it's fulfilled)
+type FutureSettled[T any] struct {
+	Value T
+	Err   error
+}
+
+// FutureDef[T] Future inspired by Ecmascript Promise/Java CompletableFuture, the result of an already-running computation
+type FutureDef[T any] struct {
+	lock      sync.Mutex
+	doneCh    chan bool
+	isDone    bool
+	result    T
+	err       error
+	callbacks []func()
+}
+
+// PromiseDef[T] The writable side of a Future(the first Resolve/Reject wins)
+type PromiseDef[T any] struct {
+	future *FutureDef[T]
+}
+
+// PromiseNewGenerics New a Promise with its pending Future
+func PromiseNewGenerics[T any]() *PromiseDef[T] {
+	return &PromiseDef[T]{
+		future: &FutureDef[T]{
+			doneCh: make(chan bool),
+		},
+	}
+}
+
+// Resolve Fulfill the Future with the value, returns false if it's settled already
+func (promiseSelf *PromiseDef[T]) Resolve(value T) bool {
+	return promiseSelf.future.settle(value, nil)
+}
+
+// Reject Reject the Future with the err, returns false if it's settled already
+func (promiseSelf *PromiseDef[T]) Reject(err error) bool {
+	return promiseSelf.future.settle(*new(T), err)
+}
+
+// Complete Resolve it if err is nil, or Reject it
+func (promiseSelf *PromiseDef[T]) Complete(value T, err error) bool {
+	return promiseSelf.future.settle(value, err)
+}
+
+// GetFuture Get the Future
+func (promiseSelf *PromiseDef[T]) GetFuture() *FutureDef[T] {
+	return promiseSelf.future
+}
+
+// FutureNewGenerics Run the fn on the Scheduler(a new goroutine if it's nil) & get its Future(the panic is rejected as a PanicError)
+func FutureNewGenerics[T any](scheduler Scheduler, fn func() (T, error)) *FutureDef[T] {
+	promise := PromiseNewGenerics[T]()
+	run := func() {
+		defer func() {
+			if r := recover(); r != nil {
+				promise.Reject(NewPanicError(r))
+			}
+		}()
+
+		promise.Complete(fn())
+	}
+
+	if scheduler == nil {
+		go run()
+	} else if err := scheduler.Schedule(run); err != nil {
+		promise.Reject(err)
+	}
+	return promise.GetFuture()
+}
+
+// FutureResolvedGenerics New a fulfilled Future
+func FutureResolvedGenerics[T any](value T) *FutureDef[T] {
+	promise := PromiseNewGenerics[T]()
+	promise.Resolve(value)
+	return promise.GetFuture()
+}
+
+// FutureRejectedGenerics New a rejected Future
+func FutureRejectedGenerics[T any](err error) *FutureDef[T] {
+	promise := PromiseNewGenerics[T]()
+	promise.Reject(err)
+	return promise.GetFuture()
+}
+
+func (futureSelf *FutureDef[T]) settle(value T, err error) bool {
+	futureSelf.lock.Lock()
+	if futureSelf.isDone {
+		futureSelf.lock.Unlock()
+		return false
+	}
+	futureSelf.isDone = true
+	futureSelf.result = value
+	futureSelf.err = err
+	callbacks := futureSelf.callbacks
+	futureSelf.callbacks = nil
+	close(futureSelf.doneCh)
+	futureSelf.lock.Unlock()
+
+	for _, callback := range callbacks {
+		callback()
+	}
+	return true
+}
+
+// onSettled Run the callback once it's settled(at once if it's settled already)
+func (futureSelf *FutureDef[T]) onSettled(callback func()) {
+	futureSelf.lock.Lock()
+	if !futureSelf.isDone {
+		futureSelf.callbacks = append(futureSelf.callbacks, callback)
+		futureSelf.lock.Unlock()
+		return
+	}
+	futureSelf.lock.Unlock()
+
+	callback()
+}
+
+// getSettled Get the outcome(call it after it's settled)
+func (futureSelf *FutureDef[T]) getSettled() (T, error) {
+	futureSelf.lock.Lock()
+	defer futureSelf.lock.Unlock()
+
+	return futureSelf.result, futureSelf.err
+}
+
+// Await Wait for the result, returns ctx.Err() if ctx is done before that
+func (futureSelf *FutureDef[T]) Await(ctx context.Context) (T, error) {
+	select {
+	case <-futureSelf.doneCh:
+		return futureSelf.getSettled()
+	case <-ctx.Done():
+		return *new(T), ctx.Err()
+	}
+}
+
+// Get Wait for the result
+func (futureSelf *FutureDef[T]) Get() (T, error) {
+	return futureSelf.Await(context.Background())
+}
+
+// Done Get the channel closed when it's settled
+func (futureSelf *FutureDef[T]) Done() <-chan bool {
+	return futureSelf.doneCh
+}
+
+// IsDone Is it settled
+func (futureSelf *FutureDef[T]) IsDone() bool {
+	futureSelf.lock.Lock()
+	defer futureSelf.lock.Unlock()
+
+	return futureSelf.isDone
+}
+
+// Then Map the fulfilled value by function(the rejection is passed through)
+func (futureSelf *FutureDef[T]) Then(fn func(T) (T, error)) *FutureDef[T] {
+	return FutureThen(futureSelf, fn)
+}
+
+// Catch Recover the rejection by function(the fulfilled value is passed through)
+func (futureSelf *FutureDef[T]) Catch(fn func(error) (T, error)) *FutureDef[T] {
+	promise := PromiseNewGenerics[T]()
+	futureSelf.onSettled(func() {
+		result, err := futureSelf.getSettled()
+		if err == nil {
+			promise.Resolve(result)
+			return
+		}
+		promise.completeSafe(func() (T, error) {
+			return fn(err)
+		})
+	})
+	return promise.GetFuture()
+}
+
+// completeSafe Complete it by the fn(the panic is rejected as a PanicError)
+func (promiseSelf *PromiseDef[T]) completeSafe(fn func() (T, error)) {
+	defer func() {
+		if r := recover(); r != nil {
+			promiseSelf.Reject(NewPanicError(r))
+		}
+	}()
+
+	promiseSelf.Complete(fn())
+}
+
+// FutureThen Map the fulfilled value of the Future to an another type by function(the rejection is passed through)
+func FutureThen[T any, R any](futureSelf *FutureDef[T], fn func(T) (R, error)) *FutureDef[R] {
+	promise := PromiseNewGenerics[R]()
+	futureSelf.onSettled(func() {
+		result, err := futureSelf.getSettled()
+		if err != nil {
+			promise.Reject(err)
+			return
+		}
+		promise.completeSafe(func() (R, error) {
+			return fn(result)
+		})
+	})
+	return promise.GetFuture()
+}
+
+// FutureFlatMap FlatMap the fulfilled value of the Future to an another Future(the rejection is passed through, rejected by ErrFutureIsNil if fn returns nil)
+func FutureFlatMap[T any, R any](futureSelf *FutureDef[T], fn func(T) *FutureDef[R]) *FutureDef[R] {
+	promise := PromiseNewGenerics[R]()
+	outer := FutureThen(futureSelf, func(result T) (*FutureDef[R], error) {
+		next := fn(result)
+		if next == nil {
+			return nil, ErrFutureIsNil
+		}
+		return next, nil
+	})
+	outer.onSettled(func() {
+		next, err := outer.getSettled()
+		if err != nil {
+			promise.Reject(err)
+			return
+		}
+		next.onSettled(func() {
+			promise.Complete(next.getSettled())
+		})
+	})
+	return promise.GetFuture()
+}
+
+// FutureAll Fulfilled with all the values(in order) when all are fulfilled, or rejected by the first rejection
+func FutureAll[T any](futures ...*FutureDef[T]) *FutureDef[[]T] {
+	promise := PromiseNewGenerics[[]T]()
+	results := make([]T, len(futures))
+	var lock sync.Mutex
+	remaining := len(futures)
+	if remaining == 0 {
+		promise.Resolve(results)
+	}
+
+	for i, future := range futures {
+		i, future := i, future
+		future.onSettled(func() {
+			result, err := future.getSettled()
+			if err != nil {
+				promise.Reject(err)
+				return
+			}
+
+			lock.Lock()
+			results[i] = result
+			remaining--
+			isAllDone := remaining == 0
+			lock.Unlock()
+			if isAllDone {
+				promise.Resolve(results)
+			}
+		})
+	}
+	return promise.GetFuture()
+}
+
+// FutureAny Fulfilled by the first fulfillment, or rejected by a FutureAggregateError when all are rejected
+func FutureAny[T any](futures ...*FutureDef[T]) *FutureDef[T] {
+	promise := PromiseNewGenerics[T]()
+	errs := make([]error, len(futures))
+	var lock sync.Mutex
+	remaining := len(futures)
+	if remaining == 0 {
+		promise.Reject(&FutureAggregateError{Errors: errs})
+	}
+
+	for i, future := range futures {
+		i, future := i, future
+		future.onSettled(func() {
+			result, err := future.getSettled()
+			if err == nil {
+				promise.Resolve(result)
+				return
+			}
+
+			lock.Lock()
+			errs[i] = err
+			remaining--
+			isAllDone := remaining == 0
+			lock.Unlock()
+			if isAllDone {
+				promise.Reject(&FutureAggregateError{Errors: errs})
+			}
+		})
+	}
+	return promise.GetFuture()
+}
+
+// FutureRace Settled by the first settled one(it's never settled if there is no Future)
+func FutureRace[T any](futures ...*FutureDef[T]) *FutureDef[T] {
+	promise := PromiseNewGenerics[T]()
+	for _, future := range futures {
+		future := future
+		future.onSettled(func() {
+			promise.Complete(future.getSettled())
+		})
+	}
+	return promise.GetFuture()
+}
+
+// FutureAllSettled Fulfilled with all the outcomes(in order) when all are settled
+func FutureAllSettled[T any](futures ...*FutureDef[T]) *FutureDef[[]FutureSettled[T]] {
+	futureSettled := make([]*FutureDef[FutureSettled[T]], len(futures))
+	for i, future := range futures {
+		future := future
+		promise := PromiseNewGenerics[FutureSettled[T]]()
+		future.onSettled(func() {
+			result, err := future.getSettled()
+			promise.Resolve(FutureSettled[T]{Value: result, Err: err})
+		})
+		futureSettled[i] = promise.GetFuture()
+	}
+	return FutureAll(futureSettled...)
+}
@@ -0,0 +1,126 @@
+package fpgo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFuture(t *testing.T) {
+	var err error
+	var result int
+	errExpected := errors.New("expected")
+
+	// Promise
+	promise := PromiseNewGenerics[int]()
+	future := promise.GetFuture()
+	assert.False(t, future.IsDone())
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = future.Await(ctx)
+	assert.Equal(t, context.DeadlineExceeded, err)
+	go promise.Resolve(1)
+	result, err = future.Get()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result)
+	assert.False(t, promise.Reject(errExpected))
+	assert.True(t, future.IsDone())
+
+	// Then & Catch
+	result, err = FutureNewGenerics(nil, func() (int, error) {
+		return 1, nil
+	}).Then(func(v int) (int, error) {
+		return v + 1, nil
+	}).Then(func(v int) (int, error) {
+		return 0, errExpected
+	}).Then(func(v int) (int, error) {
+		return 100, nil
+	}).Catch(func(err error) (int, error) {
+		assert.Equal(t, errExpected, err)
+		return 3, nil
+	}).Get()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result)
+	str, err := FutureFlatMap(FutureThen(FutureResolvedGenerics(1), func(v int) (string, error) {
+		return Maybe.Just(v).ToString(), nil
+	}), func(v string) *FutureDef[string] {
+		return FutureResolvedGenerics(v + "!")
+	}).Get()
+	assert.NoError(t, err)
+	assert.Equal(t, "1!", str)
+	_, err = FutureFlatMap(FutureResolvedGenerics(1), func(v int) *FutureDef[int] {
+		return nil
+	}).Get()
+	assert.Equal(t, ErrFutureIsNil, err)
+
+	// Panic
+	_, err = FutureNewGenerics(Handler.GetDefault(), func() (int, error) {
+		panic("oops")
+	}).Get()
+	assert.Equal(t, &PanicError{Value: "oops"}, err)
+	_, err = FutureResolvedGenerics(1).Then(func(v int) (int, error) {
+		panic("oops")
+	}).Get()
+	assert.Equal(t, &PanicError{Value: "oops"}, err)
+
+	// MonadIO
+	handler := Handler.New()
+	result, err = MonadIOJustGenerics(5).ToFuture(context.Background(), handler).Get()
+	assert.NoError(t, err)
+	assert.Equal(t, 5, result)
+	handler.Close()
+	_, err = MonadIOJustGenerics(5).ToFuture(context.Background(), handler).Get()
+	assert.Equal(t, ErrHandlerIsClosed, err)
+
+	// Ask
+	actual := ActorNewGenerics(func(self *ActorDef[*AskDef[int, int]], ask *AskDef[int, int]) {
+		ask.Reply(ask.Message * 2)
+	})
+	defer actual.Close()
+	result, err = ActorAskFutureGenerics[int, int](context.Background(), actual, 21).Get()
+	assert.NoError(t, err)
+	assert.Equal(t, 42, result)
+}
+
+func TestFutureCombinators(t *testing.T) {
+	var err error
+	errExpected := errors.New("expected")
+	delayed := func(v int, err error, delay time.Duration) *FutureDef[int] {
+		return FutureNewGenerics(nil, func() (int, error) {
+			time.Sleep(delay)
+			return v, err
+		})
+	}
+
+	// All
+	results, err := FutureAll(delayed(1, nil, 20*time.Millisecond), delayed(2, nil, 0), FutureResolvedGenerics(3)).Get()
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, results)
+	_, err = FutureAll(delayed(1, nil, time.Second), FutureRejectedGenerics[int](errExpected)).Get()
+	assert.Equal(t, errExpected, err)
+	results, err = FutureAll[int]().Get()
+	assert.NoError(t, err)
+	assert.Equal(t, []int{}, results)
+
+	// Any
+	result, err := FutureAny(FutureRejectedGenerics[int](errExpected), delayed(2, nil, 20*time.Millisecond), delayed(3, nil, time.Second)).Get()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result)
+	_, err = FutureAny(FutureRejectedGenerics[int](errExpected), delayed(0, errExpected, 0)).Get()
+	assert.Equal(t, &FutureAggregateError{Errors: []error{errExpected, errExpected}}, err)
+
+	// Race
+	_, err = FutureRace(delayed(1, nil, time.Second), delayed(0, errExpected, 0)).Get()
+	assert.Equal(t, errExpected, err)
+	result, err = FutureRace(delayed(1, nil, 0), delayed(0, errExpected, time.Second)).Get()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result)
+
+	// AllSettled
+	settled, err := FutureAllSettled(delayed(1, nil, 10*time.Millisecond), FutureRejectedGenerics[int](errExpected)).Get()
+	assert.NoError(t, err)
+	assert.Equal(t, []FutureSettled[int]{{Value: 1}, {Err: errExpected}}, settled)
+}
@@ -0,0 +1,274 @@
+package fpgo
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// generatorStopSignal The panic value unwinding a stopped producer
+type generatorStopSignal struct{}
+
+// generatorProducer The producer Cor of a Generator, the requester Cor pulls its values by YieldFrom(it runs only while a value is requested)
+type generatorProducer[T any] struct {
+	cor       *CorDef[T]
+	requester *CorDef[T]
+	isStarted bool
+
+	// op The pending request to reply by the next yield
+	op *CorOp[T]
+}
+
+func newGeneratorProducer[T any](effect func(yield func(T))) *generatorProducer[T] {
+	producer := &generatorProducer[T]{
+		// Never started, it's only the requester of YieldFrom
+		requester: CorNewGenerics[T](func() {}),
+	}
+	producer.cor = CorNewGenerics[T](func() {
+		producer.run(effect)
+	})
+	return producer
+}
+
+func (producerSelf *generatorProducer[T]) run(effect func(yield func(T))) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			if _, ok := recovered.(generatorStopSignal); !ok {
+				// Not stopped by the consumer
+				panic(recovered)
+			}
+		}
+	}()
+
+	// Wait for the first request
+	producerSelf.op = producerSelf.cor.awaitOp()
+	if producerSelf.op == nil {
+		return
+	}
+	effect(producerSelf.yield)
+}
+
+// yield Reply the value to the pending request & then pause until the next request(like YieldRef, but it doesn't run ahead)
+func (producerSelf *generatorProducer[T]) yield(value T) {
+	producerSelf.op.reply(value)
+	producerSelf.op = producerSelf.cor.awaitOp()
+	if producerSelf.op == nil {
+		// Cancelled by the consumer
+		panic(generatorStopSignal{})
+	}
+}
+
+// next Start or resume the producer & wait for its next value(called by the Generator under its lock)
+func (producerSelf *generatorProducer[T]) next() (T, bool) {
+	if !producerSelf.isStarted {
+		producerSelf.isStarted = true
+		producerSelf.cor.Start()
+	}
+
+	result, err := producerSelf.requester.YieldFromContext(context.Background(), producerSelf.cor, *new(T))
+	return result, err == nil
+}
+
+func (producerSelf *generatorProducer[T]) stop() {
+	producerSelf.cor.Cancel()
+}
+
+// err Get the panic of the effect(nil if it's finished or stopped)
+func (producerSelf *generatorProducer[T]) err() error {
+	if panicError, ok := producerSelf.cor.Err().(*PanicError); ok {
+		return panicError
+	}
+	return nil
+}
+
+// GeneratorDef[T] Generator inspired by Python/Ecmascript, it pulls values from a Cor lazily
+type GeneratorDef[T any] struct {
+	lock      sync.Mutex
+	isDone    AtomBool
+	doneCh    chan bool
+	closeOnce sync.Once
+
+	next  func() (T, bool)
+	close func()
+	err   func() error
+}
+
+func generatorNew[T any](next func() (T, bool), close func(), err func() error) *GeneratorDef[T] {
+	return &GeneratorDef[T]{
+		doneCh: make(chan bool),
+
+		next:  next,
+		close: close,
+		err:   err,
+	}
+}
+
+// GeneratorNewGenerics New a Generator of the values yielded by the effect(running in a Cor only when values are requested),
+// the effect is stopped at its pending yield when the Generator is closed or garbage collected
+func GeneratorNewGenerics[T any](effect func(yield func(T))) *GeneratorDef[T] {
+	producer := newGeneratorProducer(effect)
+	generator := generatorNew(producer.next, producer.stop, producer.err)
+	// The producer doesn't refer to the Generator, thus an abandoned one is collectable
+	runtime.SetFinalizer(generator, func(generator *GeneratorDef[T]) {
+		generator.Close()
+	})
+
+	return generator
+}
+
+// GeneratorFromArray New a Generator of the values of the list
+func GeneratorFromArray[T any](list []T) *GeneratorDef[T] {
+	i := 0
+	return generatorNew(func() (T, bool) {
+		if i >= len(list) {
+			return *new(T), false
+		}
+		i++
+		return list[i-1], true
+	}, func() {}, func() error {
+		return nil
+	})
+}
+
+// GeneratorFrom New a Generator of the values
+func GeneratorFrom[T any](list ...T) *GeneratorDef[T] {
+	return GeneratorFromArray(list)
+}
+
+// generatorOf New a Generator pulling values from the upstream(closing it closes the upstream)
+func generatorOf[T any, R any](upstream *GeneratorDef[T], next func() (R, bool)) *GeneratorDef[R] {
+	return generatorNew(next, upstream.Close, upstream.Err)
+}
+
+// GeneratorMap Map the Generator to an another type by function lazily
+func GeneratorMap[T any, R any](generatorSelf *GeneratorDef[T], fn TransformerFunctor[T, R]) *GeneratorDef[R] {
+	return generatorOf(generatorSelf, func() (R, bool) {
+		value, ok := generatorSelf.Next()
+		if !ok {
+			return *new(R), false
+		}
+		return fn(value), true
+	})
+}
+
+// GeneratorToStream Collect the remaining values of the Generator into a Stream
+func GeneratorToStream[T comparable](generatorSelf *GeneratorDef[T]) *StreamDef[T] {
+	return StreamFromArray(generatorSelf.ToArray())
+}
+
+// Next Get the next value, false if the Generator is done
+func (generatorSelf *GeneratorDef[T]) Next() (T, bool) {
+	generatorSelf.lock.Lock()
+	defer generatorSelf.lock.Unlock()
+
+	if generatorSelf.isDone.Get() {
+		return *new(T), false
+	}
+	value, ok := generatorSelf.next()
+	if !ok {
+		generatorSelf.Close()
+	}
+	return value, ok
+}
+
+// Close Stop the Generator(the effect is stopped at its pending yield & the ToChannel goroutines are released)
+func (generatorSelf *GeneratorDef[T]) Close() {
+	generatorSelf.isDone.Set(true)
+	generatorSelf.closeOnce.Do(func() {
+		close(generatorSelf.doneCh)
+	})
+	generatorSelf.close()
+}
+
+// Err Get the panic of the effect as a PanicError(nil if there is none)
+func (generatorSelf *GeneratorDef[T]) Err() error {
+	return generatorSelf.err()
+}
+
+// IsDone Is the Generator done or closed
+func (generatorSelf *GeneratorDef[T]) IsDone() bool {
+	return generatorSelf.isDone.Get()
+}
+
+// ToChannel Get the channel of the remaining values(closed when it's done) by a goroutine,
+// cancel the ctx or Close the Generator before abandoning the channel, otherwise the goroutine is blocked until that
+func (generatorSelf *GeneratorDef[T]) ToChannel(ctx context.Context) <-chan T {
+	ch := make(chan T)
+	go func() {
+		defer close(ch)
+		for {
+			value, ok := generatorSelf.Next()
+			if !ok {
+				return
+			}
+			select {
+			case ch <- value:
+			case <-ctx.Done():
+				generatorSelf.Close()
+				return
+			case <-generatorSelf.doneCh:
+				return
+			}
+		}
+	}()
+
+	return ch
+}
+
+// ToArray Collect the remaining values(it never returns for the infinite ones)
+func (generatorSelf *GeneratorDef[T]) ToArray() []T {
+	result := []T{}
+	for {
+		value, ok := generatorSelf.Next()
+		if !ok {
+			return result
+		}
+		result = append(result, value)
+	}
+}
+
+// Map Map the values by function lazily
+func (generatorSelf *GeneratorDef[T]) Map(fn TransformerFunctor[T, T]) *GeneratorDef[T] {
+	return GeneratorMap(generatorSelf, fn)
+}
+
+// Filter Filter the values by function(with the index of the upstream value) lazily
+func (generatorSelf *GeneratorDef[T]) Filter(fn func(T, int) bool) *GeneratorDef[T] {
+	i := 0
+	return generatorOf(generatorSelf, func() (T, bool) {
+		for {
+			value, ok := generatorSelf.Next()
+			if !ok {
+				return value, false
+			}
+			i++
+			if fn(value, i-1) {
+				return value, true
+			}
+		}
+	})
+}
+
+// Take Take the first count values lazily(the upstream is closed after that)
+func (generatorSelf *GeneratorDef[T]) Take(count int) *GeneratorDef[T] {
+	taken := 0
+	return generatorOf(generatorSelf, func() (T, bool) {
+		if taken >= count {
+			return *new(T), false
+		}
+		taken++
+		value, ok := generatorSelf.Next()
+		if taken >= count {
+			// Release the upstream at once
+			generatorSelf.Close()
+		}
+		return value, ok
+	})
+}
+
+// Drop Drop the first count values lazily
+func (generatorSelf *GeneratorDef[T]) Drop(count int) *GeneratorDef[T] {
+	return generatorSelf.Filter(func(_ T, i int) bool {
+		return i >= count
+	})
+}
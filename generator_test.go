@@ -0,0 +1,125 @@
+package fpgo
+
+import (
+	"context"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func waitForGoroutines(expected int) int {
+	actual := runtime.NumGoroutine()
+	for i := 0; i < 100 && actual > expected; i++ {
+		runtime.GC()
+		time.Sleep(5 * time.Millisecond)
+		actual = runtime.NumGoroutine()
+	}
+	return actual
+}
+
+func TestGenerator(t *testing.T) {
+	var value int
+	var ok bool
+
+	// Lazy
+	produced := 0
+	fibonacci := func() *GeneratorDef[int] {
+		return GeneratorNewGenerics(func(yield func(int)) {
+			a, b := 0, 1
+			for {
+				produced++
+				yield(a)
+				a, b = b, a+b
+			}
+		})
+	}
+	generator := fibonacci()
+	assert.Equal(t, 0, produced)
+	value, ok = generator.Next()
+	assert.True(t, ok)
+	assert.Equal(t, 0, value)
+	value, _ = generator.Next()
+	assert.Equal(t, 1, value)
+	assert.Equal(t, 2, produced)
+	generator.Close()
+	_, ok = generator.Next()
+	assert.False(t, ok)
+	assert.True(t, generator.IsDone())
+
+	// Finite
+	generator = GeneratorNewGenerics(func(yield func(int)) {
+		for i := 1; i <= 3; i++ {
+			yield(i)
+		}
+	})
+	assert.Equal(t, []int{1, 2, 3}, generator.ToArray())
+	_, ok = generator.Next()
+	assert.False(t, ok)
+
+	// Panic
+	generator = GeneratorNewGenerics(func(yield func(int)) {
+		yield(1)
+		panic("oops")
+	})
+	assert.Equal(t, []int{1}, generator.Take(3).ToArray())
+	assert.Equal(t, &PanicError{Value: "oops"}, generator.Err())
+
+	// Helpers
+	produced = 0
+	assert.Equal(t, []string{"1", "3", "5", "13"}, GeneratorMap(fibonacci().Filter(func(v int, i int) bool {
+		return v%2 == 1
+	}).Drop(1).Take(4), func(v int) string {
+		return Maybe.Just(v).ToString()
+	}).ToArray())
+	assert.Equal(t, 8, produced)
+	assert.Equal(t, StreamFrom(2, 4, 6), GeneratorToStream(GeneratorFrom(1, 2, 3).Map(func(v int) int {
+		return v * 2
+	})))
+
+	// Channel
+	result := []int{}
+	for v := range fibonacci().Take(5).ToChannel(context.Background()) {
+		result = append(result, v)
+	}
+	assert.Equal(t, []int{0, 1, 1, 2, 3}, result)
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := fibonacci().ToChannel(ctx)
+	assert.Equal(t, 0, <-ch)
+	cancel()
+	for range ch {
+	}
+}
+
+func TestGeneratorNotLeaking(t *testing.T) {
+	before := waitForGoroutines(0)
+	var defered int32
+	for i := 0; i < 10; i++ {
+		// Abandoned
+		generator := GeneratorNewGenerics(func(yield func(int)) {
+			defer func() {
+				atomic.AddInt32(&defered, 1)
+			}()
+			for {
+				yield(1)
+			}
+		})
+		generator.Next()
+	}
+	assert.Equal(t, before, waitForGoroutines(before))
+	assert.Equal(t, int32(10), atomic.LoadInt32(&defered))
+
+	// The abandoned channels are released by Close
+	for i := 0; i < 10; i++ {
+		generator := GeneratorNewGenerics(func(yield func(int)) {
+			for {
+				yield(1)
+			}
+		})
+		assert.Equal(t, 1, <-generator.ToChannel(context.Background()))
+		generator.Close()
+	}
+	assert.Equal(t, before, waitForGoroutines(before))
+}
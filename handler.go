@@ -1,10 +1,61 @@
 package fpgo
 
+import (
+	"errors"
+	"log"
+	"math"
+	"runtime"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrHandlerIsClosed Handler Is Closed
+	ErrHandlerIsClosed = errors.New("handler is closed")
+)
+
+const (
+	// HandlerPriorityDefault The priority of Post
+	HandlerPriorityDefault = 0
+	// HandlerPriorityFront The priority of PostAtFrontOfQueue(ahead of all the others, LIFO among themselves)
+	HandlerPriorityFront = math.MaxInt
+)
+
+// handlerMessage A queued function of the Handler
+type handlerMessage struct {
+	fn       func()
+	token    interface{}
+	priority int
+	// frontOrder The order of the HandlerPriorityFront ones(the later one runs first)
+	frontOrder uint64
+	// cancellable The CancellableDef of PostByOptions(marked done when it's taken out to run)
+	cancellable *CancellableDef
+}
+
+var defaultHandlerPanicHandler = func(panic interface{}) {
+	log.Printf("panic from handler: %v\n", panic)
+	buf := make([]byte, 4096)
+	log.Printf("panic from handler: %s\n", string(buf[:runtime.Stack(buf, false)]))
+}
+
+// handlerState The states shared by the copies of a Handler(e.g. the utils instance)
+type handlerState struct {
+	lock         sync.Mutex
+	isClosed     bool
+	queue        *PriorityQueue[*handlerMessage]
+	frontCount   uint64
+	idleHandler  func()
+	panicHandler func(interface{})
+	terminated   chan bool
+}
+
 // HandlerDef Handler inspired by Android/WebWorker
 type HandlerDef struct {
-	isClosed bool
+	*handlerState
 
-	ch chan func()
+	ch     chan func()
+	signal chan bool
+	timers *cancellableGroup
 }
 
 var defaultHandler *HandlerDef
@@ -19,33 +70,287 @@ func (handlerSelf *HandlerDef) New() *HandlerDef {
 	return handlerSelf.NewByCh(make(chan func()))
 }
 
-// NewByCh New Handler by its Channel
+// NewByCh New Handler by its Channel(functions sent to the Channel directly are of HandlerPriorityDefault, closing it means CloseAndDrain)
 func (handlerSelf *HandlerDef) NewByCh(ioCh chan func()) *HandlerDef {
-	new := HandlerDef{ch: ioCh}
+	new := HandlerDef{
+		handlerState: &handlerState{
+			queue: NewPriorityQueue(func(a, b *handlerMessage) bool {
+				if a.priority != b.priority {
+					return a.priority > b.priority
+				}
+				return a.frontOrder > b.frontOrder
+			}),
+			panicHandler: defaultHandlerPanicHandler,
+			terminated:   make(chan bool),
+		},
+		ch:     ioCh,
+		signal: make(chan bool, 1),
+		timers: newCancellableGroup(),
+	}
 	go new.run()
 
 	return &new
 }
 
-// Post Post a function to execute on the Handler
-func (handlerSelf *HandlerDef) Post(fn func()) {
-	if handlerSelf.isClosed {
-		return
+// Post Post a function to execute on the Handler(ErrHandlerIsClosed if it's closed)
+func (handlerSelf *HandlerDef) Post(fn func()) error {
+	return handlerSelf.PostWithPriority(fn, HandlerPriorityDefault)
+}
+
+// Schedule Post a function to execute on the Handler(ErrHandlerIsClosed if it's closed), as a Scheduler
+func (handlerSelf *HandlerDef) Schedule(fn func()) error {
+	return handlerSelf.Post(fn)
+}
+
+// PostWithPriority Post a function to execute on the Handler before the queued ones of lower priorities(FIFO for the same priority)
+func (handlerSelf *HandlerDef) PostWithPriority(fn func(), priority int) error {
+	if !handlerSelf.enqueue(&handlerMessage{fn: fn, priority: priority}) {
+		return ErrHandlerIsClosed
+	}
+	return nil
+}
+
+// PostAtFrontOfQueue Post a function to execute on the Handler before all the queued ones(including the earlier front ones, LIFO)
+func (handlerSelf *HandlerDef) PostAtFrontOfQueue(fn func()) error {
+	return handlerSelf.PostWithPriority(fn, HandlerPriorityFront)
+}
+
+// PostDelayed Post a function to execute on the Handler after the delay, it's cancelled if the Handler is closed before that
+func (handlerSelf *HandlerDef) PostDelayed(fn func(), delay time.Duration) *CancellableDef {
+	return handlerSelf.PostByOptions(fn, nil, HandlerPriorityDefault, delay)
+}
+
+// PostAtTime Post a function to execute on the Handler at the time, it's cancelled if the Handler is closed before that
+func (handlerSelf *HandlerDef) PostAtTime(fn func(), at time.Time) *CancellableDef {
+	return handlerSelf.PostDelayed(fn, time.Until(at))
+}
+
+// PostByOptions Post a function tagged by the token(comparable, for RemoveCallbacks) with the priority after the delay(queued at once if delay <= 0),
+// it's cancellable until it's taken out to run
+func (handlerSelf *HandlerDef) PostByOptions(fn func(), token interface{}, priority int, delay time.Duration) *CancellableDef {
+	message := &handlerMessage{fn: fn, token: token, priority: priority}
+	if delay > 0 {
+		return handlerSelf.timers.scheduleCancellable(delay, 0, token, func(cancellable *CancellableDef) bool {
+			return handlerSelf.enqueueCancellable(message, cancellable)
+		})
+	}
+
+	cancellable := &CancellableDef{}
+	if !handlerSelf.enqueueCancellable(message, cancellable) {
+		return newCancelledCancellable()
+	}
+	return cancellable
+}
+
+// enqueueCancellable Queue the message, cancelling the CancellableDef removes it unless it has been taken out to run
+func (handlerSelf *HandlerDef) enqueueCancellable(message *handlerMessage, cancellable *CancellableDef) bool {
+	cancellable.lock.Lock()
+	defer cancellable.lock.Unlock()
+	if cancellable.isCancelled {
+		return false
+	}
+
+	cancellable.dequeue = func() bool {
+		handlerSelf.lock.Lock()
+		defer handlerSelf.lock.Unlock()
+
+		return handlerSelf.queue.removeIf(func(queued *handlerMessage) bool {
+			return queued == message
+		}) > 0
+	}
+	message.cancellable = cancellable
+	return handlerSelf.enqueue(message)
+}
+
+// RemoveCallbacks Remove the queued & the delayed functions posted with the token(all of them if the token is nil), returns the count of the removed ones
+func (handlerSelf *HandlerDef) RemoveCallbacks(token interface{}) int {
+	match := func(messageToken interface{}) bool {
+		return token == nil || messageToken == token
 	}
 
-	handlerSelf.ch <- fn
+	removed := handlerSelf.timers.cancelIf(match)
+	var cancelled []*CancellableDef
+	handlerSelf.lock.Lock()
+	removed += handlerSelf.queue.removeIf(func(message *handlerMessage) bool {
+		if !match(message.token) {
+			return false
+		}
+		if message.cancellable != nil {
+			cancelled = append(cancelled, message.cancellable)
+		}
+		return true
+	})
+	handlerSelf.lock.Unlock()
+
+	// Including the delayed ones queued already
+	for _, cancellable := range cancelled {
+		cancellable.markCancelled()
+	}
+	return removed
+}
+
+// SetIdleHandler Set the function to execute on the Handler whenever the queue is drained
+func (handlerSelf *HandlerDef) SetIdleHandler(idleHandler func()) *HandlerDef {
+	handlerSelf.lock.Lock()
+	handlerSelf.idleHandler = idleHandler
+	handlerSelf.lock.Unlock()
+
+	return handlerSelf
+}
+
+// SetPanicHandler Set the panicHandler(handle/log panic inside posted functions, the Handler keeps running)
+func (handlerSelf *HandlerDef) SetPanicHandler(panicHandler func(interface{})) *HandlerDef {
+	handlerSelf.lock.Lock()
+	handlerSelf.panicHandler = panicHandler
+	handlerSelf.lock.Unlock()
+
+	return handlerSelf
+}
+
+// IsClosed Is the Handler closed
+func (handlerSelf *HandlerDef) IsClosed() bool {
+	handlerSelf.lock.Lock()
+	defer handlerSelf.lock.Unlock()
+
+	return handlerSelf.isClosed
 }
 
-// Close Close the Handler
+// Close Close the Handler, the queued functions are discarded & the delayed ones are cancelled(the running one is finished)
 func (handlerSelf *HandlerDef) Close() {
+	handlerSelf.close(true)
+}
+
+// CloseAndDrain Close the Handler after executing the queued functions(the delayed ones are cancelled)
+func (handlerSelf *HandlerDef) CloseAndDrain() {
+	handlerSelf.close(false)
+}
+
+// AwaitTermination Wait for the Handler to terminate after Close/CloseAndDrain, returns false if it's timeout
+// (don't call it on the Handler itself)
+func (handlerSelf *HandlerDef) AwaitTermination(timeout time.Duration) bool {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-handlerSelf.terminated:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+func (handlerSelf *HandlerDef) close(isDiscarded bool) {
+	handlerSelf.lock.Lock()
 	handlerSelf.isClosed = true
+	if isDiscarded {
+		handlerSelf.queue.removeIf(func(*handlerMessage) bool {
+			return true
+		})
+	}
+	handlerSelf.lock.Unlock()
+	handlerSelf.timers.close()
 
-	close(handlerSelf.ch)
+	handlerSelf.notify()
+}
+
+func (handlerSelf *HandlerDef) enqueue(message *handlerMessage) bool {
+	handlerSelf.lock.Lock()
+	if handlerSelf.isClosed {
+		handlerSelf.lock.Unlock()
+		return false
+	}
+	if message.priority == HandlerPriorityFront {
+		handlerSelf.frontCount++
+		message.frontOrder = handlerSelf.frontCount
+	}
+	handlerSelf.queue.Offer(message)
+	handlerSelf.lock.Unlock()
+
+	handlerSelf.notify()
+	return true
+}
+
+func (handlerSelf *HandlerDef) notify() {
+	select {
+	case handlerSelf.signal <- true:
+	default:
+	}
+}
+
+func (handlerSelf *HandlerDef) execute(fn func()) {
+	defer func() {
+		if panic := recover(); panic != nil {
+			handlerSelf.lock.Lock()
+			panicHandler := handlerSelf.panicHandler
+			handlerSelf.lock.Unlock()
+			if panicHandler != nil {
+				panicHandler(panic)
+			}
+		}
+	}()
+
+	fn()
 }
 
 func (handlerSelf *HandlerDef) run() {
-	for fn := range handlerSelf.ch {
-		fn()
+	defer close(handlerSelf.terminated)
+
+	ch := handlerSelf.ch
+	isIdle := true
+	for {
+		// Take in the functions sent to the Channel directly
+		for ch != nil && !handlerSelf.IsClosed() {
+			select {
+			case fn, ok := <-ch:
+				if !ok {
+					ch = nil
+					handlerSelf.CloseAndDrain()
+					continue
+				}
+				handlerSelf.lock.Lock()
+				handlerSelf.queue.Offer(&handlerMessage{fn: fn, priority: HandlerPriorityDefault})
+				handlerSelf.lock.Unlock()
+				continue
+			default:
+			}
+			break
+		}
+
+		handlerSelf.lock.Lock()
+		message, err := handlerSelf.queue.Poll()
+		idleHandler := handlerSelf.idleHandler
+		isClosed := handlerSelf.isClosed
+		handlerSelf.lock.Unlock()
+		if err == nil {
+			isIdle = false
+			if message.cancellable != nil {
+				message.cancellable.markDone()
+			}
+			handlerSelf.execute(message.fn)
+			continue
+		}
+		if isClosed {
+			return
+		}
+
+		if !isIdle {
+			isIdle = true
+			if idleHandler != nil {
+				handlerSelf.execute(idleHandler)
+				continue
+			}
+		}
+		select {
+		case fn, ok := <-ch:
+			if !ok {
+				ch = nil
+				handlerSelf.CloseAndDrain()
+				continue
+			}
+			isIdle = false
+			handlerSelf.execute(fn)
+		case <-handlerSelf.signal:
+		}
 	}
 }
 
@@ -0,0 +1,227 @@
+package fpgo
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandlerPostDelayed(t *testing.T) {
+	handler := Handler.New()
+	result := make(chan string, 10)
+
+	handler.PostDelayed(func() {
+		result <- "delayed"
+	}, 20*time.Millisecond)
+	handler.PostAtTime(func() {
+		result <- "at time"
+	}, time.Now().Add(10*time.Millisecond))
+	handler.Post(func() {
+		result <- "now"
+	})
+	cancelled := handler.PostDelayed(func() {
+		result <- "cancelled"
+	}, 10*time.Millisecond)
+	assert.True(t, cancelled.Cancel())
+	assert.False(t, cancelled.Cancel())
+	assert.True(t, cancelled.IsCancelled())
+
+	assert.Equal(t, "now", <-result)
+	assert.Equal(t, "at time", <-result)
+	assert.Equal(t, "delayed", <-result)
+
+	// Cleaned up when closed
+	pending := handler.PostDelayed(func() {
+		result <- "pending"
+	}, 10*time.Millisecond)
+	assert.Equal(t, 1, handler.timers.count())
+	handler.Close()
+	assert.True(t, pending.IsCancelled())
+	assert.Equal(t, 0, handler.timers.count())
+	assert.True(t, handler.PostDelayed(func() {}, 0).IsCancelled())
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, 0, len(result))
+}
+
+func TestHandlerPriorityAndRemoveCallbacks(t *testing.T) {
+	handler := Handler.New()
+	defer handler.Close()
+	result := make(chan string, 10)
+	idle := make(chan bool, 10)
+	handler.SetIdleHandler(func() {
+		idle <- true
+	})
+
+	// Block the Handler to queue the others
+	gate := make(chan bool)
+	handler.Post(func() {
+		<-gate
+	})
+	handler.PostWithPriority(func() {
+		result <- "low"
+	}, -1)
+	handler.Post(func() {
+		result <- "default 1"
+	})
+	handler.PostByOptions(func() {
+		result <- "removed"
+	}, "token", HandlerPriorityDefault, 0)
+	handler.Post(func() {
+		result <- "default 2"
+	})
+	handler.PostWithPriority(func() {
+		result <- "high"
+	}, 1)
+	handler.PostAtFrontOfQueue(func() {
+		result <- "front 1"
+	})
+	handler.PostAtFrontOfQueue(func() {
+		result <- "front 2"
+	})
+	queued := handler.PostByOptions(func() {
+		result <- "cancelled"
+	}, nil, HandlerPriorityDefault, 0)
+	assert.True(t, queued.Cancel())
+	assert.True(t, queued.IsCancelled())
+	delayed := handler.PostByOptions(func() {
+		result <- "removed delayed"
+	}, "token", HandlerPriorityDefault, 10*time.Millisecond)
+	assert.Equal(t, 2, handler.RemoveCallbacks("token"))
+	assert.True(t, delayed.IsCancelled())
+	gate <- true
+
+	assert.Equal(t, "front 2", <-result)
+	assert.Equal(t, "front 1", <-result)
+	assert.Equal(t, "high", <-result)
+	assert.Equal(t, "default 1", <-result)
+	assert.Equal(t, "default 2", <-result)
+	assert.Equal(t, "low", <-result)
+	assert.True(t, <-idle)
+
+	// Taken out to run already
+	ran := make(chan bool)
+	done := handler.PostByOptions(func() {
+		ran <- true
+	}, nil, HandlerPriorityDefault, 0)
+	<-ran
+	assert.True(t, done.IsDone())
+	assert.False(t, done.Cancel())
+
+	// The delayed ones are still cancellable after being queued(before running)
+	gate2 := make(chan bool)
+	handler.Post(func() {
+		<-gate2
+	})
+	delayedQueued := handler.PostByOptions(func() {
+		result <- "cancelled delayed"
+	}, nil, HandlerPriorityDefault, time.Millisecond)
+	removedQueued := handler.PostByOptions(func() {
+		result <- "removed queued"
+	}, "queued", HandlerPriorityDefault, time.Millisecond)
+	assert.Eventually(t, func() bool {
+		handler.lock.Lock()
+		defer handler.lock.Unlock()
+		return handler.queue.Count() == 2
+	}, time.Second, time.Millisecond)
+	assert.False(t, delayedQueued.IsDone())
+	assert.True(t, delayedQueued.Cancel())
+	assert.Equal(t, 1, handler.RemoveCallbacks("queued"))
+	assert.True(t, removedQueued.IsCancelled())
+	close(gate2)
+	delayedRan := handler.PostByOptions(func() {
+		ran <- true
+	}, nil, HandlerPriorityDefault, time.Millisecond)
+	<-ran
+	assert.Eventually(t, delayedRan.IsDone, time.Second, time.Millisecond)
+	assert.False(t, delayedRan.Cancel())
+
+	// Posting inside the Handler doesn't block it
+	handler.Post(func() {
+		handler.Post(func() {
+			result <- "nested"
+		})
+	})
+	assert.Equal(t, "nested", <-result)
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, 0, len(result))
+}
+
+func TestHandlerLifecycle(t *testing.T) {
+	var panics []interface{}
+	result := make(chan string, 10)
+
+	// Panics don't stop the Handler
+	handler := Handler.New().SetPanicHandler(func(panic interface{}) {
+		panics = append(panics, panic)
+	})
+	handler.Post(func() {
+		panic("oops")
+	})
+	handler.Post(func() {
+		result <- "after panic"
+	})
+	assert.Equal(t, "after panic", <-result)
+	assert.Equal(t, []interface{}{"oops"}, panics)
+
+	// CloseAndDrain executes the queued ones
+	gate := make(chan bool)
+	handler.Post(func() {
+		<-gate
+	})
+	handler.Post(func() {
+		result <- "drained"
+	})
+	handler.CloseAndDrain()
+	assert.True(t, handler.IsClosed())
+	assert.Equal(t, ErrHandlerIsClosed, handler.Post(func() {}))
+	assert.False(t, handler.AwaitTermination(10*time.Millisecond))
+	gate <- true
+	assert.True(t, handler.AwaitTermination(time.Second))
+	assert.Equal(t, "drained", <-result)
+
+	// Close discards the queued ones
+	handler = Handler.New()
+	started := make(chan bool)
+	handler.Post(func() {
+		started <- true
+		<-gate
+	})
+	<-started
+	handler.Post(func() {
+		result <- "discarded"
+	})
+	handler.Close()
+	handler.Close()
+	gate <- true
+	assert.True(t, handler.AwaitTermination(time.Second))
+	assert.Equal(t, 0, len(result))
+
+	// Closing the Channel means CloseAndDrain
+	ch := make(chan func())
+	handler = Handler.NewByCh(ch)
+	ch <- func() {
+		result <- "by channel"
+	}
+	close(ch)
+	assert.True(t, handler.AwaitTermination(time.Second))
+	assert.Equal(t, "by channel", <-result)
+	assert.Equal(t, ErrHandlerIsClosed, handler.Post(func() {}))
+
+	// Post & Close concurrently
+	handler = Handler.New()
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				handler.Post(func() {})
+			}
+		}()
+	}
+	handler.Close()
+	wg.Wait()
+	assert.True(t, handler.AwaitTermination(time.Second))
+}
@@ -1,20 +1,30 @@
 package fpgo
 
 import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
 	"reflect"
 	"strconv"
+	"strings"
+	"time"
 )
 
 var (
-	// ErrConversionUnsupported Conversion Unsupported
-	ErrConversionUnsupported = errors.New("unsupported")
+	// ErrNotConvertible Conversion Not Convertible(unsupported types or malformed strings)
+	ErrNotConvertible = errors.New("not convertible")
+	// ErrOverflow Conversion Overflow(the value is out of the range of the target type)
+	ErrOverflow = errors.New("overflow")
+	// ErrConversionUnsupported Conversion Unsupported(an alias of ErrNotConvertible)
+	ErrConversionUnsupported = ErrNotConvertible
 	// ErrConversionNil Conversion Nil
 	ErrConversionNil = errors.New("<nil>")
-	// ErrConversionNil Conversion Size Overflow
-	ErrConversionSizeOverflow = errors.New("size overflow")
+	// ErrConversionSizeOverflow Conversion Size Overflow(an alias of ErrOverflow)
+	ErrConversionSizeOverflow = ErrOverflow
 )
 
 // Maybe
@@ -30,10 +40,22 @@ type MaybeDef[T any] interface {
 	ToMaybe() MaybeDef[T]
 	ToFloat64() (float64, error)
 	ToFloat32() (float32, error)
+	ToComplex() (complex128, error)
 	ToInt() (int, error)
+	ToInt8() (int8, error)
+	ToInt16() (int16, error)
 	ToInt32() (int32, error)
 	ToInt64() (int64, error)
+	ToByte() (byte, error)
+	ToUint() (uint, error)
+	ToUint8() (uint8, error)
+	ToUint16() (uint16, error)
+	ToUint32() (uint32, error)
+	ToUint64() (uint64, error)
+	ToUintptr() (uintptr, error)
 	ToBool() (bool, error)
+	ToTime(layouts ...string) (time.Time, error)
+	ToDuration() (time.Duration, error)
 	Let(fn func())
 	Unwrap() T
 	UnwrapInterface() interface{}
@@ -45,6 +67,8 @@ type MaybeDef[T any] interface {
 	Kind() reflect.Kind
 	IsType(t reflect.Type) bool
 	IsKind(t reflect.Kind) bool
+	MarshalJSON() ([]byte, error)
+	Value() (driver.Value, error)
 }
 
 // someDef Maybe inspired by Rx/Optional/Guava/Haskell
@@ -157,718 +181,240 @@ func (maybeSelf someDef[T]) ToMaybe() MaybeDef[T] {
 	}
 }
 
-// ToFloat64 Maybe to Float64
-func (maybeSelf someDef[T]) ToFloat64() (float64, error) {
-	if maybeSelf.IsNil() {
-		return 0, ErrConversionNil
-	}
+// uintptrSize The bit size of uintptr
+const uintptrSize = 32 << (^uintptr(0) >> 63)
 
-	var ref interface{} = maybeSelf.ref
-	switch (ref).(type) {
-	default:
-		return float64(0), ErrConversionUnsupported
-	case string:
-		return strconv.ParseFloat(maybeSelf.ToString(), 64)
-	case bool:
-		val, err := maybeSelf.ToBool()
-		if val {
-			return float64(1), err
-		}
-		return float64(0), err
-	case uint:
-		val, err := maybeSelf.ToUint()
-		return float64(val), err
-	case uint16:
-		val, err := maybeSelf.ToUint16()
-		return float64(val), err
-	case uint32:
-		val, err := maybeSelf.ToUint32()
-		return float64(val), err
-	case uint64:
-		val, err := maybeSelf.ToUint64()
-		return float64(val), err
-	case uintptr:
-		val, err := maybeSelf.ToUintptr()
-		return float64(val), err
-	case byte:
-		val, err := maybeSelf.ToByte()
-		return float64(val), err
-	case int:
-		val, err := maybeSelf.ToInt()
-		return float64(val), err
-	case int8:
-		val, err := maybeSelf.ToInt8()
-		return float64(val), err
-	case int16:
-		val, err := maybeSelf.ToInt16()
-		return float64(val), err
-	case int32:
-		val, err := maybeSelf.ToInt32()
-		return float64(val), err
-	case int64:
-		val, err := maybeSelf.ToInt64()
-		return float64(val), err
-	case float32:
-		val, err := maybeSelf.ToFloat32()
-		return float64(val), err
-	case float64:
-		return (ref).(float64), nil
+// convertParseError Map the strconv errors to ErrOverflow/ErrNotConvertible
+func convertParseError(err error) error {
+	if errors.Is(err, strconv.ErrRange) {
+		return ErrOverflow
 	}
+	return ErrNotConvertible
 }
 
-// ToFloat32 Maybe to Float32
-func (maybeSelf someDef[T]) ToFloat32() (float32, error) {
+// toInt64 Maybe to a signed integer of bitSize bits(floats are rounded)
+func (maybeSelf someDef[T]) toInt64(bitSize int) (int64, error) {
 	if maybeSelf.IsNil() {
 		return 0, ErrConversionNil
 	}
 
-	var ref interface{} = maybeSelf.ref
-	switch (ref).(type) {
-	default:
-		return float32(0), ErrConversionUnsupported
-	case string:
-		val, err := strconv.ParseFloat(maybeSelf.ToString(), 32)
-		return float32(val), err
-	case bool:
-		val, err := maybeSelf.ToBool()
-		if val {
-			return float32(1), err
-		}
-		return float32(0), err
-	case uint:
-		val, err := maybeSelf.ToUint()
-		return float32(val), err
-	case uint16:
-		val, err := maybeSelf.ToUint16()
-		return float32(val), err
-	case uint32:
-		val, err := maybeSelf.ToUint32()
-		return float32(val), err
-	case uint64:
-		val, err := maybeSelf.ToUint64()
-		return float32(val), err
-	case uintptr:
-		val, err := maybeSelf.ToUintptr()
-		return float32(val), err
-	case byte:
-		val, err := maybeSelf.ToByte()
-		return float32(val), err
-	case int:
-		val, err := maybeSelf.ToInt()
-		return float32(val), err
-	case int8:
-		val, err := maybeSelf.ToInt8()
-		return float32(val), err
-	case int16:
-		val, err := maybeSelf.ToInt16()
-		return float32(val), err
-	case int32:
-		val, err := maybeSelf.ToInt32()
-		return float32(val), err
-	case int64:
-		val, err := maybeSelf.ToInt64()
-		return float32(val), err
-	case float32:
-		return (ref).(float32), nil
-	case float64:
-		val, err := maybeSelf.ToFloat64()
-		return float32(val), err
-	}
-}
-
-// ToInt Maybe to Int
-func (maybeSelf someDef[T]) ToInt() (int, error) {
-	if maybeSelf.IsNil() {
-		return 0, ErrConversionNil
-	}
-
-	var ref interface{} = maybeSelf.ref
-	switch (ref).(type) {
+	max := int64(math.MaxInt64 >> (64 - bitSize))
+	min := -max - 1
+	val := reflect.ValueOf(maybeSelf.ref)
+	switch val.Kind() {
 	default:
-		return 0, ErrConversionUnsupported
-	case string:
-		return strconv.Atoi(maybeSelf.ToString())
-	case bool:
-		val, err := maybeSelf.ToBool()
-		if val {
-			return 1, err
-		}
-		return 0, err
-	case uint:
-		val, err := maybeSelf.ToUint()
-		if val <= math.MaxInt32 {
-			return int(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case uint16:
-		val, err := maybeSelf.ToUint16()
-		return int(val), err
-	case uint32:
-		val, err := maybeSelf.ToUint32()
-		if val <= math.MaxInt32 {
-			return int(val), err
+		return 0, ErrNotConvertible
+	case reflect.String:
+		result, err := strconv.ParseInt(val.String(), 10, bitSize)
+		if err != nil {
+			return 0, convertParseError(err)
 		}
-		return 0, ErrConversionSizeOverflow
-	case uint64:
-		val, err := maybeSelf.ToUint64()
-		if val <= math.MaxInt32 {
-			return int(val), err
+		return result, nil
+	case reflect.Bool:
+		if val.Bool() {
+			return 1, nil
 		}
-		return 0, ErrConversionSizeOverflow
-	case uintptr:
-		val, err := maybeSelf.ToUintptr()
-		if val <= math.MaxInt32 {
-			return int(val), err
+		return 0, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		result := val.Int()
+		if result < min || result > max {
+			return 0, ErrOverflow
 		}
-		return 0, ErrConversionSizeOverflow
-	case byte:
-		val, err := maybeSelf.ToByte()
-		return int(val), err
-	case int:
-		return (ref).(int), nil
-	case int8:
-		val, err := maybeSelf.ToInt8()
-		return int(val), err
-	case int16:
-		val, err := maybeSelf.ToInt16()
-		return int(val), err
-	case int32:
-		val, err := maybeSelf.ToInt32()
-		if val >= math.MinInt32 && val <= math.MaxInt32 {
-			return int(val), err
+		return result, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		result := val.Uint()
+		if result > uint64(max) {
+			return 0, ErrOverflow
 		}
-		return 0, ErrConversionSizeOverflow
-	case int64:
-		val, err := maybeSelf.ToInt64()
-		if val >= math.MinInt32 && val <= math.MaxInt32 {
-			return int(val), err
+		return int64(result), nil
+	case reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		result, err := maybeSelf.toFloat64(64)
+		if err != nil {
+			return 0, err
 		}
-		return 0, ErrConversionSizeOverflow
-	case float32:
-		val, err := maybeSelf.ToFloat32()
-		if val >= math.MinInt32 && val <= math.MaxInt32 {
-			return int(math.Round(float64(val))), err
+		if math.IsNaN(result) {
+			return 0, ErrNotConvertible
 		}
-		return 0, ErrConversionSizeOverflow
-	case float64:
-		val, err := maybeSelf.ToFloat64()
-		if val >= math.MinInt32 && val <= math.MaxInt32 {
-			return int(math.Round(val)), err
+		result = math.Round(result)
+		limit := math.Ldexp(1, bitSize-1)
+		if result < -limit || result >= limit {
+			return 0, ErrOverflow
 		}
-		return 0, ErrConversionSizeOverflow
+		return int64(result), nil
 	}
 }
 
-// ToInt8 Maybe to Int8
-func (maybeSelf someDef[T]) ToInt8() (int8, error) {
+// toUint64 Maybe to an unsigned integer of bitSize bits(floats are rounded)
+func (maybeSelf someDef[T]) toUint64(bitSize int) (uint64, error) {
 	if maybeSelf.IsNil() {
 		return 0, ErrConversionNil
 	}
 
-	var ref interface{} = maybeSelf.ref
-	switch (ref).(type) {
+	max := uint64(math.MaxUint64 >> (64 - bitSize))
+	val := reflect.ValueOf(maybeSelf.ref)
+	switch val.Kind() {
 	default:
-		return int8(0), ErrConversionUnsupported
-	case string:
-		parseInt, err := strconv.ParseInt((ref).(string), 10, 8)
-		return int8(parseInt), err
-	case bool:
-		val, err := maybeSelf.ToBool()
-		if val {
-			return int8(1), err
-		}
-		return int8(0), err
-	case uint:
-		val, err := maybeSelf.ToUint()
-		if val <= math.MaxInt8 {
-			return int8(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case uint16:
-		val, err := maybeSelf.ToUint16()
-		if val <= math.MaxInt8 {
-			return int8(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case uint32:
-		val, err := maybeSelf.ToUint32()
-		if val <= math.MaxInt8 {
-			return int8(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case uint64:
-		val, err := maybeSelf.ToUint64()
-		if val <= math.MaxInt8 {
-			return int8(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case uintptr:
-		val, err := maybeSelf.ToUintptr()
-		if val <= math.MaxInt8 {
-			return int8(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case byte:
-		val, err := maybeSelf.ToByte()
-		if val <= math.MaxInt8 {
-			return int8(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case int:
-		val, err := maybeSelf.ToInt()
-		if val >= math.MinInt8 && val <= math.MaxInt8 {
-			return int8(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case int8:
-		return (ref).(int8), nil
-	case int16:
-		val, err := maybeSelf.ToInt16()
-		if val >= math.MinInt8 && val <= math.MaxInt8 {
-			return int8(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case int32:
-		val, err := maybeSelf.ToInt32()
-		if val >= math.MinInt8 && val <= math.MaxInt8 {
-			return int8(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case int64:
-		val, err := maybeSelf.ToInt64()
-		if val >= math.MinInt8 && val <= math.MaxInt8 {
-			return int8(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case float32:
-		val, err := maybeSelf.ToFloat32()
-		if val >= math.MinInt8 && val <= math.MaxInt8 {
-			return int8(math.Round(float64(val))), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case float64:
-		val, err := maybeSelf.ToFloat64()
-		if val >= math.MinInt8 && val <= math.MaxInt8 {
-			return int8(math.Round(val)), err
-		}
-		return 0, ErrConversionSizeOverflow
-	}
-}
-
-// ToInt16 Maybe to Int16
-func (maybeSelf someDef[T]) ToInt16() (int16, error) {
+		return 0, ErrNotConvertible
+	case reflect.String:
+		if strings.HasPrefix(val.String(), "-") {
+			// Negative numbers are out of the range, rather than malformed
+			_, err := strconv.ParseInt(val.String(), 10, 64)
+			if err == nil || errors.Is(err, strconv.ErrRange) {
+				return 0, ErrOverflow
+			}
+			return 0, ErrNotConvertible
+		}
+		result, err := strconv.ParseUint(val.String(), 10, bitSize)
+		if err != nil {
+			return 0, convertParseError(err)
+		}
+		return result, nil
+	case reflect.Bool:
+		if val.Bool() {
+			return 1, nil
+		}
+		return 0, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		result := val.Int()
+		if result < 0 || uint64(result) > max {
+			return 0, ErrOverflow
+		}
+		return uint64(result), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		result := val.Uint()
+		if result > max {
+			return 0, ErrOverflow
+		}
+		return result, nil
+	case reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		result, err := maybeSelf.toFloat64(64)
+		if err != nil {
+			return 0, err
+		}
+		if math.IsNaN(result) {
+			return 0, ErrNotConvertible
+		}
+		result = math.Round(result)
+		if result < 0 || result >= math.Ldexp(1, bitSize) {
+			return 0, ErrOverflow
+		}
+		return uint64(result), nil
+	}
+}
+
+// toFloat64 Maybe to a float of bitSize bits(complexes are convertible only if their imaginary parts are 0)
+func (maybeSelf someDef[T]) toFloat64(bitSize int) (float64, error) {
 	if maybeSelf.IsNil() {
 		return 0, ErrConversionNil
 	}
 
-	var ref interface{} = maybeSelf.ref
-	switch (ref).(type) {
+	var result float64
+	val := reflect.ValueOf(maybeSelf.ref)
+	switch val.Kind() {
 	default:
-		return int16(0), ErrConversionUnsupported
-	case string:
-		parseInt, err := strconv.ParseInt((ref).(string), 10, 16)
-		return int16(parseInt), err
-	case bool:
-		val, err := maybeSelf.ToBool()
-		if val {
-			return int16(1), err
-		}
-		return int16(0), err
-	case uint:
-		val, err := maybeSelf.ToUint()
-		if val <= math.MaxInt16 {
-			return int16(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case uint16:
-		val, err := maybeSelf.ToUint16()
-		if val <= math.MaxInt16 {
-			return int16(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case uint32:
-		val, err := maybeSelf.ToUint32()
-		if val <= math.MaxInt16 {
-			return int16(val), err
+		return 0, ErrNotConvertible
+	case reflect.String:
+		parsed, err := strconv.ParseFloat(val.String(), bitSize)
+		if err != nil {
+			return 0, convertParseError(err)
 		}
-		return 0, ErrConversionSizeOverflow
-	case uint64:
-		val, err := maybeSelf.ToUint64()
-		if val <= math.MaxInt16 {
-			return int16(val), err
+		result = parsed
+	case reflect.Bool:
+		if val.Bool() {
+			result = 1
 		}
-		return 0, ErrConversionSizeOverflow
-	case uintptr:
-		val, err := maybeSelf.ToUintptr()
-		if val <= math.MaxInt16 {
-			return int16(val), err
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		result = float64(val.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		result = float64(val.Uint())
+	case reflect.Float32, reflect.Float64:
+		result = val.Float()
+	case reflect.Complex64, reflect.Complex128:
+		complexVal := val.Complex()
+		if imag(complexVal) != 0 {
+			return 0, ErrNotConvertible
 		}
-		return 0, ErrConversionSizeOverflow
-	case byte:
-		val, err := maybeSelf.ToByte()
-		return int16(val), err
-	case int:
-		val, err := maybeSelf.ToInt()
-		if val >= math.MinInt16 && val <= math.MaxInt16 {
-			return int16(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case int8:
-		val, err := maybeSelf.ToInt8()
-		return int16(val), err
-	case int16:
-		return (ref).(int16), nil
-	case int32:
-		val, err := maybeSelf.ToInt32()
-		if val >= math.MinInt16 && val <= math.MaxInt16 {
-			return int16(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case int64:
-		val, err := maybeSelf.ToInt64()
-		if val >= math.MinInt16 && val <= math.MaxInt16 {
-			return int16(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case float32:
-		val, err := maybeSelf.ToFloat32()
-		if val >= math.MinInt16 && val <= math.MaxInt16 {
-			return int16(math.Round(float64(val))), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case float64:
-		val, err := maybeSelf.ToFloat64()
-		if val >= math.MinInt16 && val <= math.MaxInt16 {
-			return int16(math.Round(val)), err
-		}
-		return 0, ErrConversionSizeOverflow
+		result = real(complexVal)
 	}
+
+	if bitSize == 32 && !math.IsInf(result, 0) && math.Abs(result) > math.MaxFloat32 {
+		return 0, ErrOverflow
+	}
+	return result, nil
 }
 
-// ToInt32 Maybe to Int32
-func (maybeSelf someDef[T]) ToInt32() (int32, error) {
+// ToFloat64 Maybe to Float64
+func (maybeSelf someDef[T]) ToFloat64() (float64, error) {
+	return maybeSelf.toFloat64(64)
+}
+
+// ToFloat32 Maybe to Float32
+func (maybeSelf someDef[T]) ToFloat32() (float32, error) {
+	val, err := maybeSelf.toFloat64(32)
+	return float32(val), err
+}
+
+// ToComplex Maybe to Complex128(strings are parsed by strconv.ParseComplex)
+func (maybeSelf someDef[T]) ToComplex() (complex128, error) {
 	if maybeSelf.IsNil() {
 		return 0, ErrConversionNil
 	}
 
-	var ref interface{} = maybeSelf.ref
-	switch (ref).(type) {
-	default:
-		return int32(0), ErrConversionUnsupported
-	case string:
-		parseInt, err := strconv.ParseInt((ref).(string), 10, 32)
-		return int32(parseInt), err
-	case bool:
-		val, err := maybeSelf.ToBool()
-		if val {
-			return int32(1), err
-		}
-		return int32(0), err
-	case uint:
-		val, err := maybeSelf.ToUint()
-		if val <= math.MaxInt32 {
-			return int32(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case uint16:
-		val, err := maybeSelf.ToUint16()
-		return int32(val), err
-	case uint32:
-		val, err := maybeSelf.ToUint32()
-		if val <= math.MaxInt32 {
-			return int32(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case uint64:
-		val, err := maybeSelf.ToUint64()
-		if val <= math.MaxInt32 {
-			return int32(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case uintptr:
-		val, err := maybeSelf.ToUintptr()
-		if val <= math.MaxInt32 {
-			return int32(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case byte:
-		val, err := maybeSelf.ToByte()
-		return int32(val), err
-	case int:
-		val, err := maybeSelf.ToInt()
-		if val >= math.MinInt32 && val <= math.MaxInt32 {
-			return int32(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case int8:
-		val, err := maybeSelf.ToInt8()
-		return int32(val), err
-	case int16:
-		val, err := maybeSelf.ToInt16()
-		return int32(val), err
-	case int32:
-		return (ref).(int32), nil
-	case int64:
-		val, err := maybeSelf.ToInt64()
-		if val >= math.MinInt32 && val <= math.MaxInt32 {
-			return int32(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case float32:
-		val, err := maybeSelf.ToFloat32()
-		if val >= math.MinInt32 && val <= math.MaxInt32 {
-			return int32(math.Round(float64(val))), err
+	val := reflect.ValueOf(maybeSelf.ref)
+	switch val.Kind() {
+	case reflect.Complex64, reflect.Complex128:
+		return val.Complex(), nil
+	case reflect.String:
+		result, err := strconv.ParseComplex(val.String(), 128)
+		if err != nil {
+			return 0, convertParseError(err)
 		}
-		return 0, ErrConversionSizeOverflow
-	case float64:
-		val, err := maybeSelf.ToFloat64()
-		return int32(math.Round(val)), err
+		return result, nil
 	}
+
+	realVal, err := maybeSelf.toFloat64(64)
+	return complex(realVal, 0), err
+}
+
+// ToInt Maybe to Int
+func (maybeSelf someDef[T]) ToInt() (int, error) {
+	val, err := maybeSelf.toInt64(strconv.IntSize)
+	return int(val), err
+}
+
+// ToInt8 Maybe to Int8
+func (maybeSelf someDef[T]) ToInt8() (int8, error) {
+	val, err := maybeSelf.toInt64(8)
+	return int8(val), err
+}
+
+// ToInt16 Maybe to Int16
+func (maybeSelf someDef[T]) ToInt16() (int16, error) {
+	val, err := maybeSelf.toInt64(16)
+	return int16(val), err
+}
+
+// ToInt32 Maybe to Int32
+func (maybeSelf someDef[T]) ToInt32() (int32, error) {
+	val, err := maybeSelf.toInt64(32)
+	return int32(val), err
 }
 
 // ToInt64 Maybe to Int64
 func (maybeSelf someDef[T]) ToInt64() (int64, error) {
-	if maybeSelf.IsNil() {
-		return 0, ErrConversionNil
-	}
-
-	var ref interface{} = maybeSelf.ref
-	switch (ref).(type) {
-	default:
-		return int64(0), ErrConversionUnsupported
-	case string:
-		return strconv.ParseInt((ref).(string), 10, 64)
-	case bool:
-		val, err := maybeSelf.ToBool()
-		if val {
-			return int64(1), err
-		}
-		return int64(0), err
-	case uint:
-		val, err := maybeSelf.ToUint()
-		if val <= math.MaxInt64 {
-			return int64(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case uint16:
-		val, err := maybeSelf.ToUint16()
-		return int64(val), err
-	case uint32:
-		val, err := maybeSelf.ToUint32()
-		return int64(val), err
-	case uint64:
-		val, err := maybeSelf.ToUint64()
-		if val <= math.MaxInt64 {
-			return int64(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case uintptr:
-		val, err := maybeSelf.ToUintptr()
-		if val <= math.MaxInt64 {
-			return int64(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case byte:
-		val, err := maybeSelf.ToByte()
-		return int64(val), err
-	case int:
-		val, err := maybeSelf.ToInt()
-		return int64(val), err
-	case int8:
-		val, err := maybeSelf.ToInt8()
-		return int64(val), err
-	case int16:
-		val, err := maybeSelf.ToInt16()
-		return int64(val), err
-	case int32:
-		val, err := maybeSelf.ToInt32()
-		return int64(val), err
-	case int64:
-		return (ref).(int64), nil
-	case float32:
-		val, err := maybeSelf.ToFloat32()
-		if val >= math.MinInt64 && val <= math.MaxInt64 {
-			return int64(math.Round(float64(val))), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case float64:
-		val, err := maybeSelf.ToFloat64()
-		if val >= math.MinInt64 && val <= math.MaxInt64 {
-			return int64(math.Round(val)), err
-		}
-		return 0, ErrConversionSizeOverflow
-	}
+	return maybeSelf.toInt64(64)
 }
 
 // ToByte Maybe to Byte
 func (maybeSelf someDef[T]) ToByte() (byte, error) {
-	if maybeSelf.IsNil() {
-		return 0, ErrConversionNil
-	}
-
-	var ref interface{} = maybeSelf.ref
-	switch (ref).(type) {
-	default:
-		return uint8(0), ErrConversionUnsupported
-	case string:
-		parseInt, err := strconv.ParseInt((ref).(string), 10, 8)
-		return uint8(parseInt), err
-	case bool:
-		val, err := maybeSelf.ToBool()
-		if val {
-			return uint8(1), err
-		}
-		return uint8(0), err
-	case uint:
-		val, err := maybeSelf.ToUint()
-		if val >= 0 && val <= math.MaxUint8 {
-			return uint8(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case uint16:
-		val, err := maybeSelf.ToUint16()
-		if val >= 0 && val <= math.MaxUint8 {
-			return uint8(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case uint32:
-		val, err := maybeSelf.ToUint32()
-		if val >= 0 && val <= math.MaxUint8 {
-			return uint8(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case uint64:
-		val, err := maybeSelf.ToUint64()
-		if val >= 0 && val <= math.MaxUint8 {
-			return uint8(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case uintptr:
-		val, err := maybeSelf.ToUintptr()
-		if val >= 0 && val <= math.MaxUint8 {
-			return uint8(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case byte:
-		return ref.(byte), nil
-	case int:
-		val, err := maybeSelf.ToInt()
-		if val >= 0 && val <= math.MaxUint8 {
-			return uint8(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case int8:
-		val, err := maybeSelf.ToInt8()
-		return uint8(val), err
-	case int16:
-		val, err := maybeSelf.ToInt16()
-		if val >= 0 && val <= math.MaxUint8 {
-			return uint8(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case int32:
-		val, err := maybeSelf.ToInt32()
-		if val >= 0 && val <= math.MaxUint8 {
-			return uint8(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case int64:
-		val, err := maybeSelf.ToInt64()
-		if val >= 0 && val <= math.MaxUint8 {
-			return uint8(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case float32:
-		val, err := maybeSelf.ToFloat32()
-		if val >= 0 && val <= math.MaxUint8 {
-			return uint8(math.Round(float64(val))), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case float64:
-		val, err := maybeSelf.ToFloat64()
-		if val >= 0 && val <= math.MaxUint8 {
-			return uint8(math.Round(val)), err
-		}
-		return 0, ErrConversionSizeOverflow
-	}
+	val, err := maybeSelf.toUint64(8)
+	return byte(val), err
 }
 
 // ToUint Maybe to Uint
 func (maybeSelf someDef[T]) ToUint() (uint, error) {
-	if maybeSelf.IsNil() {
-		return 0, ErrConversionNil
-	}
-
-	var ref interface{} = maybeSelf.ref
-	switch (ref).(type) {
-	default:
-		return 0, ErrConversionUnsupported
-	case string:
-		parseInt, err := strconv.ParseInt((ref).(string), 10, 32)
-		return uint(parseInt), err
-	case bool:
-		val, err := maybeSelf.ToBool()
-		if val {
-			return 1, err
-		}
-		return 0, err
-	case uint:
-		return ref.(uint), nil
-	case uint16:
-		val, err := maybeSelf.ToUint16()
-		return uint(val), err
-	case uint32:
-		val, err := maybeSelf.ToUint32()
-		if val >= 0 && val <= math.MaxUint32 {
-			return uint(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case uint64:
-		val, err := maybeSelf.ToUint64()
-		if val >= 0 && val <= math.MaxUint32 {
-			return uint(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case uintptr:
-		val, err := maybeSelf.ToUintptr()
-		if val >= 0 && val <= math.MaxUint32 {
-			return uint(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case byte:
-		val, err := maybeSelf.ToByte()
-		return uint(val), err
-	case int:
-		val, err := maybeSelf.ToInt()
-		return uint(val), err
-	case int8:
-		val, err := maybeSelf.ToInt8()
-		return uint(val), err
-	case int16:
-		val, err := maybeSelf.ToInt16()
-		return uint(val), err
-	case int32:
-		val, err := maybeSelf.ToInt32()
-		return uint(val), err
-	case int64:
-		val, err := maybeSelf.ToInt64()
-		if val >= 0 && val <= math.MaxUint32 {
-			return uint(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case float32:
-		val, err := maybeSelf.ToFloat32()
-		if val >= 0 && val <= math.MaxUint32 {
-			return uint(math.Round(float64(val))), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case float64:
-		val, err := maybeSelf.ToFloat64()
-		if val >= 0 && val <= math.MaxUint32 {
-			return uint(math.Round(val)), err
-		}
-		return 0, ErrConversionSizeOverflow
-	}
+	val, err := maybeSelf.toUint64(strconv.IntSize)
+	return uint(val), err
 }
 
 // ToUint8 Maybe to Uint8
@@ -878,363 +424,102 @@ func (maybeSelf someDef[T]) ToUint8() (uint8, error) {
 
 // ToUint16 Maybe to Uint16
 func (maybeSelf someDef[T]) ToUint16() (uint16, error) {
-	if maybeSelf.IsNil() {
-		return 0, ErrConversionNil
-	}
-
-	var ref interface{} = maybeSelf.ref
-	switch (ref).(type) {
-	default:
-		return uint16(0), ErrConversionUnsupported
-	case string:
-		parseInt, err := strconv.ParseInt((ref).(string), 10, 16)
-		return uint16(parseInt), err
-	case bool:
-		val, err := maybeSelf.ToBool()
-		if val {
-			return uint16(1), err
-		}
-		return uint16(0), err
-	case uint:
-		val, err := maybeSelf.ToUint()
-		if val >= 0 && val <= math.MaxUint16 {
-			return uint16(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case uint16:
-		return (ref).(uint16), nil
-	case uint32:
-		val, err := maybeSelf.ToUint32()
-		if val >= 0 && val <= math.MaxUint16 {
-			return uint16(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case uint64:
-		val, err := maybeSelf.ToUint64()
-		if val >= 0 && val <= math.MaxUint16 {
-			return uint16(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case uintptr:
-		val, err := maybeSelf.ToUintptr()
-		if val >= 0 && val <= math.MaxUint16 {
-			return uint16(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case byte:
-		val, err := maybeSelf.ToByte()
-		return uint16(val), err
-	case int:
-		val, err := maybeSelf.ToInt()
-		if val >= 0 && val <= math.MaxUint16 {
-			return uint16(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case int8:
-		val, err := maybeSelf.ToInt8()
-		return uint16(val), err
-	case int16:
-		val, err := maybeSelf.ToInt32()
-		return uint16(val), err
-	case int32:
-		val, err := maybeSelf.ToInt32()
-		if val >= 0 && val <= math.MaxUint16 {
-			return uint16(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case int64:
-		val, err := maybeSelf.ToInt64()
-		if val >= 0 && val <= math.MaxUint16 {
-			return uint16(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case float32:
-		val, err := maybeSelf.ToFloat32()
-		if val >= 0 && val <= math.MaxUint16 {
-			return uint16(math.Round(float64(val))), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case float64:
-		val, err := maybeSelf.ToFloat64()
-		if val >= 0 && val <= math.MaxUint16 {
-			return uint16(math.Round(val)), err
-		}
-		return 0, ErrConversionSizeOverflow
-	}
+	val, err := maybeSelf.toUint64(16)
+	return uint16(val), err
 }
 
 // ToUint32 Maybe to Uint32
 func (maybeSelf someDef[T]) ToUint32() (uint32, error) {
-	if maybeSelf.IsNil() {
-		return 0, ErrConversionNil
-	}
-
-	var ref interface{} = maybeSelf.ref
-	switch (ref).(type) {
-	default:
-		return uint32(0), ErrConversionUnsupported
-	case string:
-		parseInt, err := strconv.ParseInt((ref).(string), 10, 32)
-		return uint32(parseInt), err
-	case bool:
-		val, err := maybeSelf.ToBool()
-		if val {
-			return uint32(1), err
-		}
-		return uint32(0), err
-	case uint:
-		val, err := maybeSelf.ToUint()
-		if val >= 0 && val <= math.MaxUint32 {
-			return uint32(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case uint16:
-		val, err := maybeSelf.ToUint16()
-		return uint32(val), err
-	case uint32:
-		return ref.(uint32), nil
-	case uint64:
-		val, err := maybeSelf.ToUint64()
-		if val >= 0 && val <= math.MaxUint32 {
-			return uint32(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case uintptr:
-		val, err := maybeSelf.ToUintptr()
-		if val >= 0 && val <= math.MaxUint32 {
-			return uint32(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case byte:
-		val, err := maybeSelf.ToByte()
-		return uint32(val), err
-	case int:
-		val, err := maybeSelf.ToInt()
-		if val >= 0 && val <= math.MaxUint32 {
-			return uint32(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case int8:
-		val, err := maybeSelf.ToInt8()
-		return uint32(val), err
-	case int16:
-		val, err := maybeSelf.ToInt16()
-		return uint32(val), err
-	case int32:
-		val, err := maybeSelf.ToInt32()
-		return uint32(val), err
-	case int64:
-		val, err := maybeSelf.ToInt64()
-		if val >= 0 && val <= math.MaxUint32 {
-			return uint32(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case float32:
-		val, err := maybeSelf.ToFloat32()
-		if val >= 0 && val <= math.MaxUint32 {
-			return uint32(math.Round(float64(val))), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case float64:
-		val, err := maybeSelf.ToFloat64()
-		return uint32(math.Round(val)), err
-	}
+	val, err := maybeSelf.toUint64(32)
+	return uint32(val), err
 }
 
 // ToUint64 Maybe to Uint64
 func (maybeSelf someDef[T]) ToUint64() (uint64, error) {
+	return maybeSelf.toUint64(64)
+}
+
+// ToUintptr Maybe to Uintptr
+func (maybeSelf someDef[T]) ToUintptr() (uintptr, error) {
+	val, err := maybeSelf.toUint64(uintptrSize)
+	return uintptr(val), err
+}
+
+// ToBool Maybe to Bool(numbers are true if they're not 0)
+func (maybeSelf someDef[T]) ToBool() (bool, error) {
 	if maybeSelf.IsNil() {
-		return 0, ErrConversionNil
+		return false, ErrConversionNil
 	}
 
-	var ref interface{} = maybeSelf.ref
-	switch (ref).(type) {
-	default:
-		return uint64(0), ErrConversionUnsupported
-	case string:
-		parseInt, err := strconv.ParseInt((ref).(string), 10, 64)
-		return uint64(parseInt), err
-	case bool:
-		val, err := maybeSelf.ToBool()
-		if val {
-			return uint64(1), err
-		}
-		return uint64(0), err
-	case uint:
-		val, err := maybeSelf.ToUint()
-		return uint64(val), err
-	case uint16:
-		val, err := maybeSelf.ToUint16()
-		return uint64(val), err
-	case uint32:
-		val, err := maybeSelf.ToUint32()
-		return uint64(val), err
-	case uint64:
-		return ref.(uint64), nil
-	case uintptr:
-		val, err := maybeSelf.ToUintptr()
-		if val >= 0 && val <= math.MaxUint64 {
-			return uint64(val), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case byte:
-		val, err := maybeSelf.ToByte()
-		return uint64(val), err
-	case int:
-		val, err := maybeSelf.ToInt()
-		return uint64(val), err
-	case int8:
-		val, err := maybeSelf.ToInt8()
-		return uint64(val), err
-	case int16:
-		val, err := maybeSelf.ToInt16()
-		return uint64(val), err
-	case int32:
-		val, err := maybeSelf.ToInt32()
-		return uint64(val), err
-	case int64:
-		val, err := maybeSelf.ToInt64()
-		return uint64(val), err
-	case float32:
-		val, err := maybeSelf.ToFloat32()
-		if val >= 0 && val <= math.MaxUint64 {
-			return uint64(math.Round(float64(val))), err
-		}
-		return 0, ErrConversionSizeOverflow
-	case float64:
-		val, err := maybeSelf.ToFloat64()
-		if val >= 0 && val <= math.MaxUint64 {
-			return uint64(math.Round(val)), err
+	val := reflect.ValueOf(maybeSelf.ref)
+	switch val.Kind() {
+	case reflect.Bool:
+		return val.Bool(), nil
+	case reflect.String:
+		result, err := strconv.ParseBool(val.String())
+		if err != nil {
+			return false, ErrNotConvertible
 		}
-		return 0, ErrConversionSizeOverflow
+		return result, nil
+	case reflect.Complex64, reflect.Complex128:
+		return val.Complex() != 0, nil
 	}
+
+	result, err := maybeSelf.toFloat64(64)
+	return result != 0, err
 }
 
-// ToUintptr Maybe to Uintptr
-func (maybeSelf someDef[T]) ToUintptr() (uintptr, error) {
+// ToTime Maybe to Time(strings are parsed by the layouts(time.RFC3339Nano by default), integers are Unix seconds)
+func (maybeSelf someDef[T]) ToTime(layouts ...string) (time.Time, error) {
 	if maybeSelf.IsNil() {
-		return 0, ErrConversionNil
+		return time.Time{}, ErrConversionNil
 	}
-	// Uintptr size might differ between different CPUs
-	maxUintptr := uint64(^uintptr(0))
 
 	var ref interface{} = maybeSelf.ref
-	switch (ref).(type) {
-	default:
-		return uintptr(0), ErrConversionUnsupported
+	switch val := ref.(type) {
+	case time.Time:
+		return val, nil
 	case string:
-		parseInt, err := strconv.ParseInt((ref).(string), 10, 64)
-		if uint64(parseInt) <= maxUintptr {
-			return uintptr(parseInt), err
+		if len(layouts) == 0 {
+			layouts = []string{time.RFC3339Nano}
 		}
-		return uintptr(0), ErrConversionSizeOverflow
-	case bool:
-		val, err := maybeSelf.ToBool()
-		if val {
-			return uintptr(1), err
+		for _, layout := range layouts {
+			if result, err := time.Parse(layout, val); err == nil {
+				return result, nil
+			}
 		}
-		return uintptr(0), err
-	case uint:
-		val, err := maybeSelf.ToUint()
-		return uintptr(val), err
-	case uint16:
-		val, err := maybeSelf.ToUint16()
-		return uintptr(val), err
-	case uint32:
-		val, err := maybeSelf.ToUint32()
-		return uintptr(val), err
-	case uint64:
-		val, err := maybeSelf.ToUint64()
-		if val <= maxUintptr {
-			return uintptr(val), err
-		}
-		return uintptr(0), ErrConversionSizeOverflow
-	case uintptr:
-		return ref.(uintptr), nil
-	case byte:
-		val, err := maybeSelf.ToByte()
-		return uintptr(val), err
-	case int:
-		val, err := maybeSelf.ToInt()
-		return uintptr(val), err
-	case int8:
-		val, err := maybeSelf.ToInt8()
-		return uintptr(val), err
-	case int16:
-		val, err := maybeSelf.ToInt16()
-		return uintptr(val), err
-	case int32:
-		val, err := maybeSelf.ToInt32()
-		return uintptr(val), err
-	case int64:
-		val, err := maybeSelf.ToInt64()
-		if uint64(val) <= maxUintptr {
-			return uintptr(val), err
+		return time.Time{}, ErrNotConvertible
+	}
+
+	switch maybeSelf.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		seconds, err := maybeSelf.ToInt64()
+		if err != nil {
+			return time.Time{}, err
 		}
-		return uintptr(0), ErrConversionSizeOverflow
-	case float32:
-		val, err := maybeSelf.ToFloat32()
-		return uintptr(math.Round(float64(val))), err
-	case float64:
-		val, err := maybeSelf.ToFloat64()
-		return uintptr(math.Round(val)), err
+		return time.Unix(seconds, 0), nil
 	}
+	return time.Time{}, ErrNotConvertible
 }
 
-// ToBool Maybe to Bool
-func (maybeSelf someDef[T]) ToBool() (bool, error) {
+// ToDuration Maybe to Duration(strings are parsed by time.ParseDuration, numbers are nanoseconds)
+func (maybeSelf someDef[T]) ToDuration() (time.Duration, error) {
 	if maybeSelf.IsNil() {
-		return false, ErrConversionNil
+		return 0, ErrConversionNil
 	}
 
 	var ref interface{} = maybeSelf.ref
-	switch (ref).(type) {
-	default:
-		return false, ErrConversionUnsupported
-	case string:
-		return strconv.ParseBool(maybeSelf.ToString())
-	case bool:
-		return (ref).(bool), nil
-	case uint:
-		val, err := maybeSelf.ToUint()
-		return val != 0, err
-	case uint16:
-		val, err := maybeSelf.ToUint16()
-		return val != 0, err
-	case uint32:
-		val, err := maybeSelf.ToUint32()
-		return val != 0, err
-	case uint64:
-		val, err := maybeSelf.ToUint64()
-		return val != 0, err
-	case uintptr:
-		val, err := maybeSelf.ToUintptr()
-		return val != 0, err
-	case byte:
-		val, err := maybeSelf.ToByte()
-		return val != 0, err
-	case int:
-		val, err := maybeSelf.ToInt()
-		return val != 0, err
-	case int8:
-		val, err := maybeSelf.ToInt8()
-		return val != 0, err
-	case int16:
-		val, err := maybeSelf.ToInt16()
-		return val != 0, err
-	case int32:
-		val, err := maybeSelf.ToInt32()
-		return val != 0, err
-	case int64:
-		val, err := maybeSelf.ToInt64()
-		return val != 0, err
-	case float32:
-		val, err := maybeSelf.ToFloat32()
-		return val != 0, err
-	case float64:
-		val, err := maybeSelf.ToFloat64()
-		return val != 0, err
+	if val, ok := ref.(string); ok {
+		result, err := time.ParseDuration(val)
+		if err != nil {
+			return 0, ErrNotConvertible
+		}
+		return result, nil
 	}
+
+	val, err := maybeSelf.toInt64(64)
+	return time.Duration(val), err
 }
 
 // Let If the wrapped value is not nil, then do the given function
@@ -1268,9 +553,10 @@ func (maybeSelf someDef[T]) IsPresent() bool {
 	// return !(maybeSelf.IsNil())
 }
 
-// IsNil Check is it nil
+// IsNil Check is it nil(or absent: the zero value, e.g. the one of MaybeValueDef, is nil as well)
 func (maybeSelf someDef[T]) IsNil() bool {
-	return maybeSelf.isNil
+	// Not only isNil: an absent zero value must not be treated as present by Or()/Let()/etc
+	return maybeSelf.isNil || !maybeSelf.isPresent
 
 	// return IsNil(maybeSelf.ref)
 	//
@@ -1316,116 +602,356 @@ func (maybeSelf someDef[T]) IsKind(t reflect.Kind) bool {
 	return maybeSelf.Kind() == t
 }
 
+// MarshalJSON Maybe to JSON(null if it's absent)
+func (maybeSelf someDef[T]) MarshalJSON() ([]byte, error) {
+	if !maybeSelf.IsPresent() {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(maybeSelf.ref)
+}
+
+// Value Maybe to a SQL driver.Value(NULL if it's absent)
+func (maybeSelf someDef[T]) Value() (driver.Value, error) {
+	if !maybeSelf.IsPresent() {
+		return nil, nil
+	}
+
+	return driver.DefaultParameterConverter.ConvertValue(maybeSelf.ref)
+}
+
+// MaybeValueDef[T] The concrete Maybe for the fields of DTOs/DB rows(the zero value is absent),
+// it implements json.Unmarshaler & sql.Scanner(null/NULL to None) in addition to MaybeDef[T]
+type MaybeValueDef[T any] struct {
+	someDef[T]
+}
+
+// MaybeValueOf New a MaybeValueDef by a given Maybe
+func MaybeValueOf[T any](maybe MaybeDef[T]) MaybeValueDef[T] {
+	if !maybe.IsPresent() {
+		return MaybeValueDef[T]{}
+	}
+
+	return MaybeValueDef[T]{someDef[T]{ref: maybe.Unwrap(), isPresent: true}}
+}
+
+// Get Get it as a MaybeDef(None if it's absent)
+func (maybeSelf MaybeValueDef[T]) Get() MaybeDef[T] {
+	if !maybeSelf.IsPresent() {
+		return NoneOf[T]()
+	}
+
+	return maybeSelf.someDef
+}
+
+// UnmarshalJSON JSON to Maybe(null to None)
+func (maybeSelf *MaybeValueDef[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*maybeSelf = MaybeValueDef[T]{}
+		return nil
+	}
+
+	var ref T
+	err := json.Unmarshal(data, &ref)
+	if err != nil {
+		return err
+	}
+	*maybeSelf = MaybeValueOf(JustGenerics(ref))
+	return nil
+}
+
+// Scan SQL value to Maybe(NULL to None)
+func (maybeSelf *MaybeValueDef[T]) Scan(src interface{}) error {
+	if src == nil {
+		*maybeSelf = MaybeValueDef[T]{}
+		return nil
+	}
+
+	var ref T
+	switch val := src.(type) {
+	case T:
+		ref = val
+	default:
+		if scanner, ok := interface{}(&ref).(sql.Scanner); ok {
+			if err := scanner.Scan(src); err != nil {
+				return err
+			}
+			break
+		}
+
+		srcValue := reflect.ValueOf(src)
+		refValue := reflect.ValueOf(&ref).Elem()
+		if !isScanConvertible(srcValue.Kind(), refValue.Kind()) || !srcValue.CanConvert(refValue.Type()) {
+			return ErrNotConvertible
+		}
+		refValue.Set(srcValue.Convert(refValue.Type()))
+	}
+	*maybeSelf = MaybeValueOf(JustGenerics(ref))
+	return nil
+}
+
+// isScanConvertible Check is it a reasonable conversion of the SQL values(numbers between numbers, bytes/strings between bytes/strings)
+func isScanConvertible(src reflect.Kind, dest reflect.Kind) bool {
+	isNumber := func(kind reflect.Kind) bool {
+		return (kind >= reflect.Int && kind <= reflect.Float64)
+	}
+	isText := func(kind reflect.Kind) bool {
+		return kind == reflect.String || kind == reflect.Slice
+	}
+	return (isNumber(src) && isNumber(dest)) || (isText(src) && isText(dest))
+}
+
 // Maybe Maybe utils instance
 var Maybe someDef[interface{}]
 
+// MaybeMap Map the present value to an another type by function(an absent one is passed through)
+func MaybeMap[T any, R any](maybeSelf MaybeDef[T], fn func(T) R) MaybeDef[R] {
+	if !maybeSelf.IsPresent() {
+		return NoneOf[R]()
+	}
+
+	return JustGenerics(fn(maybeSelf.Unwrap()))
+}
+
+// MaybeFlatMap FlatMap the present value to an another Maybe by function(an absent one is passed through)
+func MaybeFlatMap[T any, R any](maybeSelf MaybeDef[T], fn func(T) MaybeDef[R]) MaybeDef[R] {
+	if !maybeSelf.IsPresent() {
+		return NoneOf[R]()
+	}
+
+	return fn(maybeSelf.Unwrap())
+}
+
+// MaybeFilter Keep the present value only if it matches the predicate
+func MaybeFilter[T any](maybeSelf MaybeDef[T], fn Predicate[T]) MaybeDef[T] {
+	return MaybeFlatMap(maybeSelf, func(value T) MaybeDef[T] {
+		if !fn(value) {
+			return NoneOf[T]()
+		}
+		return maybeSelf
+	})
+}
+
+// MaybeZip Combine the two present values by function(absent if any of them is absent)
+func MaybeZip[T any, R any, X any](maybe1 MaybeDef[T], maybe2 MaybeDef[R], fn func(T, R) X) MaybeDef[X] {
+	return MaybeFlatMap(maybe1, func(value1 T) MaybeDef[X] {
+		return MaybeMap(maybe2, func(value2 R) X {
+			return fn(value1, value2)
+		})
+	})
+}
+
+// MaybeOrElseGet Get the present value, or the one supplied by function lazily
+func MaybeOrElseGet[T any](maybeSelf MaybeDef[T], fn func() T) T {
+	if !maybeSelf.IsPresent() {
+		return fn()
+	}
+
+	return maybeSelf.Unwrap()
+}
+
+// MaybeToSlice Get a slice of the present value(empty if it's absent)
+func MaybeToSlice[T any](maybeSelf MaybeDef[T]) []T {
+	if !maybeSelf.IsPresent() {
+		return []T{}
+	}
+
+	return []T{maybeSelf.Unwrap()}
+}
+
+// MaybeToInt Convert the present value to an Int Maybe(absent if it's not convertible, see ToInt())
+func MaybeToInt[T any](maybeSelf MaybeDef[T]) MaybeDef[int] {
+	return MaybeFlatMap(maybeSelf, func(value T) MaybeDef[int] {
+		return ResultOf(JustGenerics(value).ToInt()).ToMaybe()
+	})
+}
+
+// MaybeToFloat64 Convert the present value to a Float64 Maybe(absent if it's not convertible, see ToFloat64())
+func MaybeToFloat64[T any](maybeSelf MaybeDef[T]) MaybeDef[float64] {
+	return MaybeFlatMap(maybeSelf, func(value T) MaybeDef[float64] {
+		return ResultOf(JustGenerics(value).ToFloat64()).ToMaybe()
+	})
+}
+
 // None
 
 // noneDef None inspired by Rx/Optional/Guava/Haskell
-type noneDef struct {
-	someDef[interface{}]
+type noneDef[T any] struct {
+	someDef[T]
+}
+
+// NoneOf New an absent Maybe of the type T(None is the one of interface{})
+func NoneOf[T any]() MaybeDef[T] {
+	return noneDef[T]{someDef[T]{isNil: true, isPresent: false}}
 }
 
 // Or Check the value wrapped by Maybe, if it's nil then return a given fallback value
-func (noneSelf noneDef) Or(or interface{}) interface{} {
+func (noneSelf noneDef[T]) Or(or T) T {
 	return or
 }
 
 // CloneTo Clone the Ptr target to an another Ptr target
-func (noneSelf noneDef) CloneTo(dest interface{}) MaybeDef[interface{}] {
-	return None
+func (noneSelf noneDef[T]) CloneTo(dest T) MaybeDef[T] {
+	return noneSelf
 }
 
 // Clone Clone Maybe object & its wrapped value
-func (noneSelf noneDef) Clone() MaybeDef[interface{}] {
-	return None
+func (noneSelf noneDef[T]) Clone() MaybeDef[T] {
+	return noneSelf
 }
 
 // ToString Maybe to String
-func (noneSelf noneDef) ToString() string {
+func (noneSelf noneDef[T]) ToString() string {
 	return "<nil>"
 }
 
 // ToPtr Maybe to Ptr
-func (noneSelf noneDef) ToPtr() *interface{} {
+func (noneSelf noneDef[T]) ToPtr() *T {
 	return nil
 }
 
 // ToMaybe Maybe to Maybe
-func (noneSelf noneDef) ToMaybe() MaybeDef[interface{}] {
+func (noneSelf noneDef[T]) ToMaybe() MaybeDef[T] {
 	return noneSelf
 }
 
 // ToFloat64 Maybe to Float64
-func (noneSelf noneDef) ToFloat64() (float64, error) {
+func (noneSelf noneDef[T]) ToFloat64() (float64, error) {
 	return float64(0), ErrConversionNil
 }
 
 // ToFloat32 Maybe to Float32
-func (noneSelf noneDef) ToFloat32() (float32, error) {
+func (noneSelf noneDef[T]) ToFloat32() (float32, error) {
 	return float32(0), ErrConversionNil
 }
 
 // ToInt Maybe to Int
-func (noneSelf noneDef) ToInt() (int, error) {
+func (noneSelf noneDef[T]) ToInt() (int, error) {
 	return int(0), ErrConversionNil
 }
 
 // ToInt32 Maybe to Int32
-func (noneSelf noneDef) ToInt32() (int32, error) {
+func (noneSelf noneDef[T]) ToInt32() (int32, error) {
 	return int32(0), ErrConversionNil
 }
 
 // ToInt64 Maybe to Int64
-func (noneSelf noneDef) ToInt64() (int64, error) {
+func (noneSelf noneDef[T]) ToInt64() (int64, error) {
 	return int64(0), ErrConversionNil
 }
 
 // ToBool Maybe to Bool
-func (noneSelf noneDef) ToBool() (bool, error) {
+func (noneSelf noneDef[T]) ToBool() (bool, error) {
 	return bool(false), ErrConversionNil
 }
 
+// ToComplex Maybe to Complex
+func (noneSelf noneDef[T]) ToComplex() (complex128, error) {
+	return complex128(0), ErrConversionNil
+}
+
+// ToInt8 Maybe to Int8
+func (noneSelf noneDef[T]) ToInt8() (int8, error) {
+	return int8(0), ErrConversionNil
+}
+
+// ToInt16 Maybe to Int16
+func (noneSelf noneDef[T]) ToInt16() (int16, error) {
+	return int16(0), ErrConversionNil
+}
+
+// ToByte Maybe to Byte
+func (noneSelf noneDef[T]) ToByte() (byte, error) {
+	return byte(0), ErrConversionNil
+}
+
+// ToUint Maybe to Uint
+func (noneSelf noneDef[T]) ToUint() (uint, error) {
+	return uint(0), ErrConversionNil
+}
+
+// ToUint8 Maybe to Uint8
+func (noneSelf noneDef[T]) ToUint8() (uint8, error) {
+	return uint8(0), ErrConversionNil
+}
+
+// ToUint16 Maybe to Uint16
+func (noneSelf noneDef[T]) ToUint16() (uint16, error) {
+	return uint16(0), ErrConversionNil
+}
+
+// ToUint32 Maybe to Uint32
+func (noneSelf noneDef[T]) ToUint32() (uint32, error) {
+	return uint32(0), ErrConversionNil
+}
+
+// ToUint64 Maybe to Uint64
+func (noneSelf noneDef[T]) ToUint64() (uint64, error) {
+	return uint64(0), ErrConversionNil
+}
+
+// ToUintptr Maybe to Uintptr
+func (noneSelf noneDef[T]) ToUintptr() (uintptr, error) {
+	return uintptr(0), ErrConversionNil
+}
+
+// ToTime Maybe to Time
+func (noneSelf noneDef[T]) ToTime(layouts ...string) (time.Time, error) {
+	return time.Time{}, ErrConversionNil
+}
+
+// ToDuration Maybe to Duration
+func (noneSelf noneDef[T]) ToDuration() (time.Duration, error) {
+	return time.Duration(0), ErrConversionNil
+}
+
 // Let If the wrapped value is not nil, then do the given function
-func (noneSelf noneDef) Let(fn func()) {}
+func (noneSelf noneDef[T]) Let(fn func()) {}
 
-// Unwrap Unwrap the wrapped value of Maybe
-func (noneSelf noneDef) Unwrap() interface{} {
-	return nil
+// Unwrap Unwrap the wrapped value of Maybe(the zero value of T)
+func (noneSelf noneDef[T]) Unwrap() T {
+	return *new(T)
 }
 
 // UnwrapInterface Unwrap the wrapped value of Maybe as interface{}
-func (noneSelf noneDef) UnwrapInterface() interface{} {
+func (noneSelf noneDef[T]) UnwrapInterface() interface{} {
 	return nil
 }
 
 // IsPresent Check is it present(not nil)
-func (noneSelf noneDef) IsPresent() bool {
+func (noneSelf noneDef[T]) IsPresent() bool {
 	return false
 }
 
 // IsNil Check is it nil
-func (noneSelf noneDef) IsNil() bool {
+func (noneSelf noneDef[T]) IsNil() bool {
 	return true
 }
 
 // IsPtr Check is it Ptr
-func (noneSelf noneDef) IsPtr() bool {
+func (noneSelf noneDef[T]) IsPtr() bool {
 	return false
 }
 
 // Type Get its Type
-func (noneSelf noneDef) Type() reflect.Type {
+func (noneSelf noneDef[T]) Type() reflect.Type {
 	return reflect.TypeOf(nil)
 }
 
 // Kind Get its Kind
-func (noneSelf noneDef) Kind() reflect.Kind {
+func (noneSelf noneDef[T]) Kind() reflect.Kind {
 	return reflect.Invalid
 }
 
-// None None utils instance
-var None = noneDef{someDef[any]{isNil: true, isPresent: false}}
+// MarshalJSON Maybe to JSON(null if it's absent)
+func (noneSelf noneDef[T]) MarshalJSON() ([]byte, error) {
+	return []byte("null"), nil
+}
+
+// Value Maybe to a SQL driver.Value(NULL if it's absent)
+func (noneSelf noneDef[T]) Value() (driver.Value, error) {
+	return nil, nil
+}
 
-// // var noneAsSome = someDef[interface{}](None)
-// var noneAsSome = None.someDef
+// None None utils instance(an absent Maybe of interface{}, see NoneOf for the typed ones)
+var None = noneDef[interface{}]{someDef[interface{}]{isNil: true, isPresent: false}}
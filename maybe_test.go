@@ -1,9 +1,13 @@
 package fpgo
 
 import (
+	"database/sql/driver"
+	"encoding/json"
 	"errors"
+	"math"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -322,3 +326,232 @@ func TestCast(t *testing.T) {
 	assert.Equal(t, false, b)
 	assert.Equal(t, errors.New("<nil>"), err)
 }
+
+func TestMaybeCombinators(t *testing.T) {
+	var iptr *int
+	absent := JustGenerics(iptr)
+	i := 1
+
+	// Map & FlatMap
+	assert.Equal(t, "2", MaybeMap(JustGenerics(2), func(v int) string {
+		return Maybe.Just(v).ToString()
+	}).Unwrap())
+	assert.False(t, MaybeMap(absent, func(v *int) int {
+		return *v
+	}).IsPresent())
+	assert.Equal(t, 1, MaybeFlatMap(JustGenerics(&i), func(v *int) MaybeDef[int] {
+		return JustGenerics(*v)
+	}).Unwrap())
+	assert.False(t, MaybeFlatMap(absent, func(v *int) MaybeDef[int] {
+		return JustGenerics(*v)
+	}).IsPresent())
+
+	// Filter & Zip
+	isEven := func(v int) bool {
+		return v%2 == 0
+	}
+	assert.Equal(t, 2, MaybeFilter(JustGenerics(2), isEven).Unwrap())
+	assert.False(t, MaybeFilter(JustGenerics(1), isEven).IsPresent())
+	add := func(v1 int, v2 *int) int {
+		return v1 + *v2
+	}
+	assert.Equal(t, 3, MaybeZip(JustGenerics(2), JustGenerics(&i), add).Unwrap())
+	assert.False(t, MaybeZip(JustGenerics(2), absent, add).IsPresent())
+
+	// OrElseGet & ToSlice
+	called := false
+	assert.Equal(t, &i, MaybeOrElseGet(absent, func() *int {
+		called = true
+		return &i
+	}))
+	assert.True(t, called)
+	called = false
+	assert.Equal(t, 2, MaybeOrElseGet(JustGenerics(2), func() int {
+		called = true
+		return 0
+	}))
+	assert.False(t, called)
+	assert.Equal(t, []int{2}, MaybeToSlice(JustGenerics(2)))
+	assert.Equal(t, []*int{}, MaybeToSlice(absent))
+
+	// Conversions
+	assert.Equal(t, 12, MaybeToInt(JustGenerics("12")).Unwrap())
+	assert.False(t, MaybeToInt(JustGenerics("x")).IsPresent())
+	assert.False(t, MaybeToInt(absent).IsPresent())
+	assert.Equal(t, 1.5, MaybeToFloat64(JustGenerics("1.5")).Unwrap())
+	assert.Equal(t, float64(1), MaybeToFloat64(Maybe.Just(true)).Unwrap())
+	assert.False(t, MaybeToFloat64(JustGenerics([]int{})).IsPresent())
+}
+
+func TestNone(t *testing.T) {
+	var m MaybeDef[int]
+
+	m = NoneOf[int]()
+	assert.False(t, m.IsPresent())
+	assert.True(t, m.IsNil())
+	assert.Equal(t, 3, m.Or(3))
+	assert.Equal(t, 0, m.Unwrap())
+	assert.Nil(t, m.ToPtr())
+	_, err := m.ToInt()
+	assert.Equal(t, ErrConversionNil, err)
+	assert.Equal(t, 2, MaybeMap(NoneOf[string](), func(string) int {
+		return 1
+	}).Or(2))
+	assert.Equal(t, None, Maybe.Just(nil))
+	assert.Equal(t, NoneOf[interface{}](), Maybe.Just(nil))
+	assert.False(t, None.IsPresent())
+}
+
+func TestMaybeJSON(t *testing.T) {
+	type user struct {
+		Name MaybeValueDef[string] `json:"name"`
+		Age  MaybeValueDef[int]    `json:"age"`
+	}
+
+	// Marshal
+	data, err := json.Marshal(user{Name: MaybeValueOf(JustGenerics("Tom"))})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"name":"Tom","age":null}`, string(data))
+	data, err = json.Marshal([]MaybeDef[int]{JustGenerics(1), NoneOf[int]()})
+	assert.NoError(t, err)
+	assert.Equal(t, `[1,null]`, string(data))
+
+	// Unmarshal
+	var result user
+	assert.NoError(t, json.Unmarshal([]byte(`{"name":null,"age":18}`), &result))
+	assert.False(t, result.Name.IsPresent())
+	assert.Equal(t, NoneOf[string](), result.Name.Get())
+	assert.Equal(t, 18, result.Age.Unwrap())
+	assert.Error(t, json.Unmarshal([]byte(`{"age":"x"}`), &result))
+}
+
+func TestMaybeSQL(t *testing.T) {
+	var _ driver.Valuer = NoneOf[int]()
+
+	// Value
+	val, err := JustGenerics(1).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), val)
+	val, err = NoneOf[int]().Value()
+	assert.NoError(t, err)
+	assert.Nil(t, val)
+
+	// Scan
+	var number MaybeValueDef[int]
+	assert.NoError(t, number.Scan(int64(2)))
+	assert.Equal(t, 2, number.Unwrap())
+	assert.NoError(t, number.Scan(nil))
+	assert.False(t, number.IsPresent())
+	// The zero value is nil as well
+	assert.True(t, number.IsNil())
+	assert.Equal(t, 3, number.Or(3))
+	assert.Equal(t, ErrConversionUnsupported, number.Scan("2"))
+	var text MaybeValueDef[string]
+	assert.NoError(t, text.Scan([]byte("text")))
+	assert.Equal(t, "text", text.Unwrap())
+	val, err = text.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "text", val)
+}
+
+func TestCastMatrix(t *testing.T) {
+	type age int
+	var err error
+
+	// Integers
+	i8, err := Maybe.Just(int64(127)).ToInt8()
+	assert.Equal(t, int8(127), i8)
+	assert.NoError(t, err)
+	_, err = Maybe.Just(int64(128)).ToInt8()
+	assert.Equal(t, ErrOverflow, err)
+	_, err = Maybe.Just("-32769").ToInt16()
+	assert.Equal(t, ErrOverflow, err)
+	_, err = Maybe.Just(uint64(math.MaxUint64)).ToInt64()
+	assert.Equal(t, ErrOverflow, err)
+	i, err := JustGenerics(age(18)).ToInt()
+	assert.Equal(t, 18, i)
+	assert.NoError(t, err)
+	i64, err := Maybe.Just(int64(math.MaxInt64)).ToInt64()
+	assert.Equal(t, int64(math.MaxInt64), i64)
+	assert.NoError(t, err)
+	_, err = Maybe.Just(float64(math.MaxInt64)).ToInt64()
+	assert.Equal(t, ErrOverflow, err)
+	_, err = Maybe.Just(math.NaN()).ToInt()
+	assert.Equal(t, ErrNotConvertible, err)
+	_, err = Maybe.Just("1x").ToInt()
+	assert.Equal(t, ErrNotConvertible, err)
+	_, err = Maybe.Just([]int{}).ToInt()
+	assert.Equal(t, ErrNotConvertible, err)
+
+	// Unsigned integers
+	u8, err := Maybe.Just(255).ToUint8()
+	assert.Equal(t, uint8(255), u8)
+	assert.NoError(t, err)
+	_, err = Maybe.Just(256).ToByte()
+	assert.Equal(t, ErrOverflow, err)
+	_, err = Maybe.Just(-1).ToUint()
+	assert.Equal(t, ErrOverflow, err)
+	_, err = Maybe.Just("-1").ToUint32()
+	assert.Equal(t, ErrOverflow, err)
+	u16, err := Maybe.Just("65535").ToUint16()
+	assert.Equal(t, uint16(65535), u16)
+	assert.NoError(t, err)
+	u64, err := Maybe.Just(1.6).ToUint64()
+	assert.Equal(t, uint64(2), u64)
+	assert.NoError(t, err)
+	uptr, err := Maybe.Just(true).ToUintptr()
+	assert.Equal(t, uintptr(1), uptr)
+	assert.NoError(t, err)
+
+	// Floats & Complexes
+	_, err = Maybe.Just(math.MaxFloat64).ToFloat32()
+	assert.Equal(t, ErrOverflow, err)
+	_, err = Maybe.Just("1e400").ToFloat64()
+	assert.Equal(t, ErrOverflow, err)
+	f64, err := Maybe.Just(complex(1.5, 0)).ToFloat64()
+	assert.Equal(t, 1.5, f64)
+	assert.NoError(t, err)
+	_, err = Maybe.Just(complex(1, 1)).ToFloat64()
+	assert.Equal(t, ErrNotConvertible, err)
+	c, err := Maybe.Just("1+2i").ToComplex()
+	assert.Equal(t, complex(1, 2), c)
+	assert.NoError(t, err)
+	c, err = Maybe.Just(3).ToComplex()
+	assert.Equal(t, complex(3, 0), c)
+	assert.NoError(t, err)
+	_, err = Maybe.Just("yes").ToBool()
+	assert.Equal(t, ErrNotConvertible, err)
+
+	// Time & Duration
+	now := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	tm, err := Maybe.Just(now.Format(time.RFC3339)).ToTime()
+	assert.True(t, now.Equal(tm))
+	assert.NoError(t, err)
+	tm, err = Maybe.Just("2020-01-02").ToTime(time.RFC3339, "2006-01-02")
+	assert.True(t, time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC).Equal(tm))
+	assert.NoError(t, err)
+	tm, err = Maybe.Just(now.Unix()).ToTime()
+	assert.True(t, now.Equal(tm))
+	assert.NoError(t, err)
+	_, err = Maybe.Just("today").ToTime()
+	assert.Equal(t, ErrNotConvertible, err)
+	d, err := Maybe.Just("1m30s").ToDuration()
+	assert.Equal(t, 90*time.Second, d)
+	assert.NoError(t, err)
+	d, err = Maybe.Just(time.Second).ToDuration()
+	assert.Equal(t, time.Second, d)
+	assert.NoError(t, err)
+	_, err = Maybe.Just("1 day").ToDuration()
+	assert.Equal(t, ErrNotConvertible, err)
+
+	// None
+	m := NoneOf[int]()
+	_, err = m.ToUint16()
+	assert.Equal(t, ErrConversionNil, err)
+	_, err = m.ToComplex()
+	assert.Equal(t, ErrConversionNil, err)
+	_, err = m.ToTime()
+	assert.Equal(t, ErrConversionNil, err)
+	_, err = m.ToDuration()
+	assert.Equal(t, ErrConversionNil, err)
+}
@@ -1,16 +1,88 @@
 package fpgo
 
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+)
+
 // MonadIODef MonadIO inspired by Rx/Observable
 type MonadIODef[T any] struct {
-	effect func() T
+	effect func(context.Context) (T, error)
 
-	obOn  *HandlerDef
-	subOn *HandlerDef
+	obOn  Scheduler
+	subOn Scheduler
 }
 
 // Subscription the delegation/callback of MonadIO/Publisher
 type Subscription[T any] struct {
-	OnNext func(T)
+	OnNext     func(T)
+	OnError    func(error)
+	OnComplete func()
+
+	// isChained Subscribed by a chained Publisher(delivered directly, the chained one schedules on its own Scheduler)
+	isChained bool
+	// buffer The per-subscriber buffer of the Publisher
+	buffer *subscriptionBuffer
+	// replayGate Hold the live values of the Publisher until the replay has been delivered
+	replayGate *subscriptionReplayGate
+}
+
+// DroppedCount The number of values dropped by the per-subscriber buffer(always 0 if it's unbuffered)
+func (subscriptionSelf *Subscription[T]) DroppedCount() int64 {
+	if subscriptionSelf.buffer == nil {
+		return 0
+	}
+
+	return atomic.LoadInt64(&subscriptionSelf.buffer.droppedCount)
+}
+
+// PanicError The error recovered from a panic(Value is the original panic value)
+type PanicError struct {
+	Value interface{}
+}
+
+// Error The error message of the panic
+func (panicErrorSelf *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", panicErrorSelf.Value)
+}
+
+// NewPanicError New a PanicError by the recovered value(it's returned directly if it's already a PanicError)
+func NewPanicError(value interface{}) *PanicError {
+	if panicError, ok := value.(*PanicError); ok {
+		return panicError
+	}
+
+	return &PanicError{Value: value}
+}
+
+func (subscriptionSelf *Subscription[T]) doNext(in T) {
+	if subscriptionSelf.OnNext == nil {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			// Nobody handles it, keep the original behavior
+			if subscriptionSelf.OnError == nil {
+				panic(r)
+			}
+			subscriptionSelf.OnError(NewPanicError(r))
+		}
+	}()
+
+	subscriptionSelf.OnNext(in)
+}
+
+func (subscriptionSelf *Subscription[T]) doError(err error) {
+	if subscriptionSelf.OnError != nil {
+		subscriptionSelf.OnError(err)
+	}
+}
+
+func (subscriptionSelf *Subscription[T]) doComplete() {
+	if subscriptionSelf.OnComplete != nil {
+		subscriptionSelf.OnComplete()
+	}
 }
 
 // Just New MonadIO by a given value
@@ -20,8 +92,8 @@ func (monadIOSelf MonadIODef[T]) Just(in interface{}) *MonadIODef[interface{}] {
 
 // MonadIOJustGenerics New MonadIO by a given value
 func MonadIOJustGenerics[T any](in T) *MonadIODef[T] {
-	return &MonadIODef[T]{effect: func() T {
-		return in
+	return &MonadIODef[T]{effect: func(_ context.Context) (T, error) {
+		return in, nil
 	}}
 }
 
@@ -32,54 +104,129 @@ func (monadIOSelf *MonadIODef[T]) New(effect func() T) *MonadIODef[T] {
 
 // MonadIONewGenerics New MonadIO by effect function
 func MonadIONewGenerics[T any](effect func() T) *MonadIODef[T] {
+	return &MonadIODef[T]{effect: func(_ context.Context) (T, error) {
+		return effect(), nil
+	}}
+}
+
+// NewWithContext New MonadIO by a context-aware effect function
+func (monadIOSelf *MonadIODef[T]) NewWithContext(effect func(context.Context) (T, error)) *MonadIODef[T] {
+	return MonadIONewWithContext(effect)
+}
+
+// MonadIONewWithContext New MonadIO by a context-aware effect function
+func MonadIONewWithContext[T any](effect func(context.Context) (T, error)) *MonadIODef[T] {
 	return &MonadIODef[T]{effect: effect}
 }
 
 // FlatMap FlatMap the MonadIO by function
 func (monadIOSelf *MonadIODef[T]) FlatMap(fn func(T) *MonadIODef[T]) *MonadIODef[T] {
-	return &MonadIODef[T]{effect: func() T {
-		next := fn(monadIOSelf.doEffect())
-		return next.doEffect()
-	}}
+	return MonadIOFlatMap(monadIOSelf, fn)
+}
+
+// MonadIOMap Map the MonadIO to an another type by function(keeping its ObserveOn/SubscribeOn Schedulers)
+func MonadIOMap[T any, R any](monadIOSelf *MonadIODef[T], fn func(T) R) *MonadIODef[R] {
+	return &MonadIODef[R]{
+		effect: func(ctx context.Context) (R, error) {
+			result, err := monadIOSelf.doEffect(ctx)
+			if err != nil {
+				return *new(R), err
+			}
+
+			return fn(result), nil
+		},
+
+		obOn:  monadIOSelf.obOn,
+		subOn: monadIOSelf.subOn,
+	}
+}
+
+// MonadIOFlatMap FlatMap the MonadIO to an another type by function(keeping its ObserveOn/SubscribeOn Schedulers)
+func MonadIOFlatMap[T any, R any](monadIOSelf *MonadIODef[T], fn func(T) *MonadIODef[R]) *MonadIODef[R] {
+	return &MonadIODef[R]{
+		effect: func(ctx context.Context) (R, error) {
+			result, err := monadIOSelf.doEffect(ctx)
+			if err != nil {
+				return *new(R), err
+			}
+
+			next := fn(result)
+			return next.doEffect(ctx)
+		},
+
+		obOn:  monadIOSelf.obOn,
+		subOn: monadIOSelf.subOn,
+	}
 }
 
 // Subscribe Subscribe the MonadIO by Subscription
 func (monadIOSelf *MonadIODef[T]) Subscribe(s Subscription[T]) *Subscription[T] {
+	return monadIOSelf.SubscribeContext(context.Background(), s)
+}
+
+// SubscribeContext Subscribe the MonadIO by Subscription, the work stops when the ctx is done
+func (monadIOSelf *MonadIODef[T]) SubscribeContext(ctx context.Context, s Subscription[T]) *Subscription[T] {
 	obOn := monadIOSelf.obOn
 	subOn := monadIOSelf.subOn
-	return monadIOSelf.doSubscribe(&s, obOn, subOn)
+	return monadIOSelf.doSubscribe(ctx, &s, obOn, subOn)
 }
 
-// SubscribeOn Subscribe the MonadIO on the specific Handler
-func (monadIOSelf *MonadIODef[T]) SubscribeOn(h *HandlerDef) *MonadIODef[T] {
+// SubscribeOn Subscribe the MonadIO on the specific Scheduler(e.g. Handler)
+func (monadIOSelf *MonadIODef[T]) SubscribeOn(h Scheduler) *MonadIODef[T] {
 	monadIOSelf.subOn = h
 	return monadIOSelf
 }
 
-// ObserveOn Observe the MonadIO on the specific Handler
-func (monadIOSelf *MonadIODef[T]) ObserveOn(h *HandlerDef) *MonadIODef[T] {
+// ObserveOn Observe the MonadIO on the specific Scheduler(e.g. Handler)
+func (monadIOSelf *MonadIODef[T]) ObserveOn(h Scheduler) *MonadIODef[T] {
 	monadIOSelf.obOn = h
 	return monadIOSelf
 }
 
-func (monadIOSelf *MonadIODef[T]) doSubscribe(s *Subscription[T], obOn *HandlerDef, subOn *HandlerDef) *Subscription[T] {
-	if s.OnNext != nil {
+// ToFuture Run the MonadIO on the specific Scheduler(e.g. Handler, a new goroutine if it's nil) at once & get its Future
+func (monadIOSelf *MonadIODef[T]) ToFuture(ctx context.Context, scheduler Scheduler) *FutureDef[T] {
+	return FutureNewGenerics(scheduler, func() (T, error) {
+		return monadIOSelf.doEffectSafe(ctx)
+	})
+}
+
+func (monadIOSelf *MonadIODef[T]) doSubscribe(ctx context.Context, s *Subscription[T], obOn Scheduler, subOn Scheduler) *Subscription[T] {
+	if s.OnNext != nil || s.OnError != nil || s.OnComplete != nil {
 		var result T
+		var err error
 
 		doSub := func() {
-			s.OnNext(result)
+			// Cancelled during the handler hop
+			if ctx.Err() != nil {
+				return
+			}
+
+			if err != nil {
+				// Nobody handles the panic of the effect, rethrow it like doNext
+				if panicError, ok := err.(*PanicError); ok && s.OnError == nil {
+					panic(panicError.Value)
+				}
+				s.doError(err)
+				return
+			}
+			s.doNext(result)
+			s.doComplete()
 		}
 		doOb := func() {
-			result = monadIOSelf.doEffect()
+			result, err = monadIOSelf.doEffectSafe(ctx)
+			// Cancelled: nobody is waiting for the result
+			if ctx.Err() != nil {
+				return
+			}
 
 			if subOn != nil {
-				subOn.Post(doSub)
+				subOn.Schedule(doSub)
 			} else {
 				doSub()
 			}
 		}
 		if obOn != nil {
-			obOn.Post(doOb)
+			obOn.Schedule(doOb)
 		} else {
 			doOb()
 		}
@@ -88,13 +235,35 @@ func (monadIOSelf *MonadIODef[T]) doSubscribe(s *Subscription[T], obOn *HandlerD
 	return s
 }
 
-func (monadIOSelf *MonadIODef[T]) doEffect() T {
-	return monadIOSelf.effect()
+func (monadIOSelf *MonadIODef[T]) doEffect(ctx context.Context) (T, error) {
+	// Don't start the effect if it has been cancelled
+	if err := ctx.Err(); err != nil {
+		return *new(T), err
+	}
+
+	return monadIOSelf.effect(ctx)
+}
+
+func (monadIOSelf *MonadIODef[T]) doEffectSafe(ctx context.Context) (result T, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = *new(T)
+			err = NewPanicError(r)
+		}
+	}()
+
+	return monadIOSelf.doEffect(ctx)
 }
 
 // Eval Eval the value right now(sync)
 func (monadIOSelf *MonadIODef[T]) Eval() T {
-	return monadIOSelf.doEffect()
+	result, _ := monadIOSelf.doEffect(context.Background())
+	return result
+}
+
+// EvalContext Eval the value right now(sync) with the ctx, returning the error(or the recovered panic) of the effect or ctx.Err()
+func (monadIOSelf *MonadIODef[T]) EvalContext(ctx context.Context) (T, error) {
+	return monadIOSelf.doEffectSafe(ctx)
 }
 
 // MonadIO MonadIO utils instance
@@ -1,7 +1,12 @@
 package fpgo
 
 import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -40,3 +45,251 @@ func TestMonadIO(t *testing.T) {
 	m.Eval()
 	assert.Equal(t, 3, actualInt)
 }
+
+func TestMonadIOContext(t *testing.T) {
+	var m *MonadIODef[int]
+	var actualInt int
+	var err error
+
+	// EvalContext
+	m = MonadIONewWithContext(func(ctx context.Context) (int, error) {
+		return 1, nil
+	}).FlatMap(func(in int) *MonadIODef[int] {
+		return MonadIOJustGenerics(in + 1)
+	})
+	actualInt, err = m.EvalContext(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, actualInt)
+
+	// Errors stop FlatMap chains
+	errExpected := errors.New("effect error")
+	isFlatMapCalled := false
+	m = MonadIONewWithContext(func(ctx context.Context) (int, error) {
+		return 0, errExpected
+	}).FlatMap(func(in int) *MonadIODef[int] {
+		isFlatMapCalled = true
+		return MonadIOJustGenerics(in + 1)
+	})
+	_, err = m.EvalContext(context.Background())
+	assert.Equal(t, errExpected, err)
+	assert.False(t, isFlatMapCalled)
+
+	// Cancelled before evaluation
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	isEffectCalled := false
+	m = MonadIONewWithContext(func(ctx context.Context) (int, error) {
+		isEffectCalled = true
+		return 1, nil
+	})
+	_, err = m.EvalContext(ctx)
+	assert.Equal(t, context.Canceled, err)
+	assert.False(t, isEffectCalled)
+
+	// Cancelled during a FlatMap chain
+	ctx, cancel = context.WithCancel(context.Background())
+	m = MonadIONewWithContext(func(ctx context.Context) (int, error) {
+		return 1, nil
+	}).FlatMap(func(in int) *MonadIODef[int] {
+		cancel()
+		return MonadIONewWithContext(func(ctx context.Context) (int, error) {
+			isEffectCalled = true
+			return in + 1, nil
+		})
+	})
+	_, err = m.EvalContext(ctx)
+	assert.Equal(t, context.Canceled, err)
+	assert.False(t, isEffectCalled)
+
+	// Blocking effects observe ctx.Done()
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	m = MonadIONewWithContext(func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	_, err = m.EvalContext(ctx)
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	// Cancelled during the ObserveOn/SubscribeOn handler hops
+	obOn := Handler.New()
+	defer obOn.Close()
+	subOn := Handler.New()
+	defer subOn.Close()
+	ctx, cancel = context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	actualInt = 0
+	m = MonadIONewWithContext(func(ctx context.Context) (int, error) {
+		cancel()
+		return 3, nil
+	}).ObserveOn(obOn).SubscribeOn(subOn)
+	m.SubscribeContext(ctx, Subscription[int]{
+		OnNext: func(in int) {
+			actualInt = in
+		},
+	})
+	// Wait for both hops
+	obOn.Post(func() {
+		subOn.Post(wg.Done)
+	})
+	wg.Wait()
+	assert.Equal(t, 0, actualInt)
+
+	// Not cancelled
+	wg.Add(1)
+	m = MonadIONewWithContext(func(ctx context.Context) (int, error) {
+		return 3, nil
+	}).ObserveOn(obOn).SubscribeOn(subOn)
+	m.SubscribeContext(context.Background(), Subscription[int]{
+		OnNext: func(in int) {
+			actualInt = in
+			wg.Done()
+		},
+	})
+	wg.Wait()
+	assert.Equal(t, 3, actualInt)
+}
+
+func TestMonadIOErrorAndComplete(t *testing.T) {
+	var m *MonadIODef[int]
+	var actualInt int
+	var actualErr error
+	var isCompleted bool
+	subscription := Subscription[int]{
+		OnNext: func(in int) {
+			actualInt = in
+		},
+		OnError: func(err error) {
+			actualErr = err
+		},
+		OnComplete: func() {
+			isCompleted = true
+		},
+	}
+
+	// Completed after OnNext
+	m = MonadIOJustGenerics(1)
+	m.Subscribe(subscription)
+	assert.Equal(t, 1, actualInt)
+	assert.NoError(t, actualErr)
+	assert.True(t, isCompleted)
+
+	// Errors of the effect
+	actualInt, actualErr, isCompleted = 0, nil, false
+	errExpected := errors.New("effect error")
+	m = MonadIONewWithContext(func(ctx context.Context) (int, error) {
+		return 1, errExpected
+	})
+	m.Subscribe(subscription)
+	assert.Equal(t, 0, actualInt)
+	assert.Equal(t, errExpected, actualErr)
+	assert.False(t, isCompleted)
+
+	// Panics of the effect
+	actualInt, actualErr, isCompleted = 0, nil, false
+	m = MonadIOJustGenerics(1).FlatMap(func(in int) *MonadIODef[int] {
+		panic("flatMap panic")
+	})
+	m.Subscribe(subscription)
+	assert.Equal(t, 0, actualInt)
+	assert.IsType(t, &PanicError{}, actualErr)
+	assert.Equal(t, "flatMap panic", actualErr.(*PanicError).Value)
+	assert.False(t, isCompleted)
+	_, actualErr = m.EvalContext(context.Background())
+	assert.IsType(t, &PanicError{}, actualErr)
+	assert.PanicsWithValue(t, "flatMap panic", func() {
+		m.Subscribe(Subscription[int]{
+			OnNext: subscription.OnNext,
+		})
+	})
+
+	// Panics of OnNext
+	actualInt, actualErr, isCompleted = 0, nil, false
+	m = MonadIOJustGenerics(1)
+	m.Subscribe(Subscription[int]{
+		OnNext: func(in int) {
+			panic("onNext panic")
+		},
+		OnError: subscription.OnError,
+	})
+	assert.Equal(t, "onNext panic", actualErr.(*PanicError).Value)
+
+	// On handlers
+	obOn := Handler.New()
+	defer obOn.Close()
+	subOn := Handler.New()
+	defer subOn.Close()
+	var wg sync.WaitGroup
+	wg.Add(1)
+	actualInt, actualErr, isCompleted = 0, nil, false
+	m = MonadIONewWithContext(func(ctx context.Context) (int, error) {
+		return 0, errExpected
+	}).ObserveOn(obOn).SubscribeOn(subOn)
+	m.Subscribe(Subscription[int]{
+		OnError: func(err error) {
+			actualErr = err
+			wg.Done()
+		},
+	})
+	wg.Wait()
+	assert.Equal(t, errExpected, actualErr)
+}
+
+func TestMonadIOMapAndFlatMap(t *testing.T) {
+	var actual string
+	var err error
+
+	// Map: int -> string
+	m := MonadIOMap(MonadIOJustGenerics(1), func(in int) string {
+		return strconv.Itoa(in + 1)
+	})
+	actual, err = m.EvalContext(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "2", actual)
+
+	// FlatMap: int -> string
+	m = MonadIOFlatMap(MonadIOJustGenerics(1), func(in int) *MonadIODef[string] {
+		return MonadIOJustGenerics(strconv.Itoa(in + 2))
+	})
+	actual, err = m.EvalContext(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "3", actual)
+
+	// Errors are kept
+	errExpected := errors.New("effect error")
+	m = MonadIOMap(MonadIONewWithContext(func(ctx context.Context) (int, error) {
+		return 0, errExpected
+	}), func(in int) string {
+		return strconv.Itoa(in)
+	})
+	_, err = m.EvalContext(context.Background())
+	assert.Equal(t, errExpected, err)
+
+	// Handlers are kept
+	obOn := Handler.New()
+	defer obOn.Close()
+	subOn := Handler.New()
+	defer subOn.Close()
+	source := MonadIOJustGenerics(4).ObserveOn(obOn).SubscribeOn(subOn)
+	m = MonadIOMap(source, func(in int) string {
+		return strconv.Itoa(in)
+	})
+	assert.Equal(t, obOn, m.obOn)
+	assert.Equal(t, subOn, m.subOn)
+	m = MonadIOFlatMap(source, func(in int) *MonadIODef[string] {
+		return MonadIOJustGenerics(strconv.Itoa(in))
+	})
+	assert.Equal(t, obOn, m.obOn)
+	assert.Equal(t, subOn, m.subOn)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	m.Subscribe(Subscription[string]{
+		OnNext: func(in string) {
+			actual = in
+			wg.Done()
+		},
+	})
+	wg.Wait()
+	assert.Equal(t, "4", actual)
+}
@@ -124,11 +124,16 @@ func (simpleHTTPSelf *SimpleHTTPDef) recursiveVisit(request *http.Request, index
 
 // GetContextTimeout Get Context by TimeoutMillisecond
 func (simpleHTTPSelf *SimpleHTTPDef) GetContextTimeout() (context.Context, context.CancelFunc) {
+	return simpleHTTPSelf.GetContextTimeoutWithParent(context.Background())
+}
+
+// GetContextTimeoutWithParent Get Context derived from the parent by TimeoutMillisecond
+func (simpleHTTPSelf *SimpleHTTPDef) GetContextTimeoutWithParent(parent context.Context) (context.Context, context.CancelFunc) {
 	if simpleHTTPSelf.TimeoutMillisecond > 0 {
-		return context.WithTimeout(context.Background(), time.Duration(simpleHTTPSelf.TimeoutMillisecond))
+		return context.WithTimeout(parent, time.Duration(simpleHTTPSelf.TimeoutMillisecond))
 	}
 
-	return context.WithTimeout(context.Background(), DefaultTimeoutMillisecond)
+	return context.WithTimeout(parent, DefaultTimeoutMillisecond)
 }
 
 // Get HTTP Method Get
@@ -398,7 +403,7 @@ func APIMakePatchMultipartBody[R any](simpleAPISelf *SimpleAPIDef, relativeURL s
 // APIMakeDoNewRequestWithBodySerializer Make a API with request body options
 func APIMakeDoNewRequestWithBodySerializer[T any, R any](simpleAPISelf *SimpleAPIDef, method string, relativeURL string, contentType string, bodySerializer BodySerializer) APIHasBody[T, R] {
 	return APIHasBody[T, R](func(pathParam PathParam, body T, target *R) *fpgo.MonadIODef[*APIResponse[R]] {
-		return fpgo.MonadIONewGenerics[*APIResponse[R]](func() *APIResponse[R] {
+		return fpgo.MonadIONewWithContext[*APIResponse[R]](func(parent context.Context) (*APIResponse[R], error) {
 			var bodyReader io.Reader
 			if !fpgo.IsNil(body) {
 				var newBodyReaderErr error
@@ -409,21 +414,21 @@ func APIMakeDoNewRequestWithBodySerializer[T any, R any](simpleAPISelf *SimpleAP
 						ResponseWithError: ResponseWithError{
 							Err: newBodyReaderErr,
 						},
-					}
+					}, nil
 				}
 			}
 
-			ctx, cancel := simpleAPISelf.GetSimpleHTTP().GetContextTimeout()
+			ctx, cancel := simpleAPISelf.GetSimpleHTTP().GetContextTimeoutWithParent(parent)
 			defer cancel()
 			response := simpleAPISelf.simpleHTTP.DoNewRequestWithBodyOptions(ctx, simpleAPISelf.DefaultHeader.Clone(), method, simpleAPISelf.replacePathParams(relativeURL, pathParam), bodyReader, contentType)
 			if response.Err != nil {
 				return &APIResponse[R]{
 					ResponseWithError: *response,
-				}
+				}, nil
 			}
 			return decodeResponseBody[R](simpleAPISelf, &APIResponse[R]{
 				ResponseWithError: *response,
-			}, target)
+			}, target), nil
 		})
 	})
 }
@@ -431,7 +436,7 @@ func APIMakeDoNewRequestWithBodySerializer[T any, R any](simpleAPISelf *SimpleAP
 // APIMakeDoNewRequestWithMultipartSerializer Make a API with request body options
 func APIMakeDoNewRequestWithMultipartSerializer[R any](simpleAPISelf *SimpleAPIDef, method string, relativeURL string, multipartSerializer MultipartSerializer) APIMultipart[R] {
 	return APIMultipart[R](func(pathParam PathParam, body *MultipartForm, target *R) *fpgo.MonadIODef[*APIResponse[R]] {
-		return fpgo.MonadIONewGenerics[*APIResponse[R]](func() *APIResponse[R] {
+		return fpgo.MonadIONewWithContext[*APIResponse[R]](func(parent context.Context) (*APIResponse[R], error) {
 			var bodyReader io.Reader
 			var contentType string
 			if !fpgo.IsNil(body) {
@@ -443,21 +448,21 @@ func APIMakeDoNewRequestWithMultipartSerializer[R any](simpleAPISelf *SimpleAPID
 						ResponseWithError: ResponseWithError{
 							Err: newBodyReaderErr,
 						},
-					}
+					}, nil
 				}
 			}
 
-			ctx, cancel := simpleAPISelf.GetSimpleHTTP().GetContextTimeout()
+			ctx, cancel := simpleAPISelf.GetSimpleHTTP().GetContextTimeoutWithParent(parent)
 			defer cancel()
 			response := simpleAPISelf.simpleHTTP.DoNewRequestWithBodyOptions(ctx, simpleAPISelf.DefaultHeader.Clone(), method, simpleAPISelf.replacePathParams(relativeURL, pathParam), bodyReader, contentType)
 			if response.Err != nil {
 				return &APIResponse[R]{
 					ResponseWithError: *response,
-				}
+				}, nil
 			}
 			return decodeResponseBody[R](simpleAPISelf, &APIResponse[R]{
 				ResponseWithError: *response,
-			}, target)
+			}, target), nil
 		})
 	})
 }
@@ -465,18 +470,18 @@ func APIMakeDoNewRequestWithMultipartSerializer[R any](simpleAPISelf *SimpleAPID
 // APIMakeDoNewRequest Make a API without body options
 func APIMakeDoNewRequest[R any](simpleAPISelf *SimpleAPIDef, method string, relativeURL string) APINoBody[R] {
 	return APINoBody[R](func(pathParam PathParam, target *R) *fpgo.MonadIODef[*APIResponse[R]] {
-		return fpgo.MonadIONewGenerics[*APIResponse[R]](func() *APIResponse[R] {
-			ctx, cancel := simpleAPISelf.GetSimpleHTTP().GetContextTimeout()
+		return fpgo.MonadIONewWithContext[*APIResponse[R]](func(parent context.Context) (*APIResponse[R], error) {
+			ctx, cancel := simpleAPISelf.GetSimpleHTTP().GetContextTimeoutWithParent(parent)
 			defer cancel()
 			response := simpleAPISelf.simpleHTTP.DoNewRequest(ctx, simpleAPISelf.DefaultHeader.Clone(), method, simpleAPISelf.replacePathParams(relativeURL, pathParam))
 			if response.Err != nil {
 				return &APIResponse[R]{
 					ResponseWithError: *response,
-				}
+				}, nil
 			}
 			return decodeResponseBody[R](simpleAPISelf, &APIResponse[R]{
 				ResponseWithError: *response,
-			}, target)
+			}, target), nil
 		})
 	})
 }
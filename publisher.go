@@ -1,14 +1,54 @@
 package fpgo
 
-import "sync"
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	// ErrSubscriptionBufferIsFull Subscription Buffer Is Full(by BackpressureError)
+	ErrSubscriptionBufferIsFull = errors.New("subscription buffer is full")
+)
+
+// BackpressureStrategy The overflow strategy of per-subscriber buffers
+type BackpressureStrategy int
+
+const (
+	// BackpressureBlock Block the Publisher until the buffer has space
+	BackpressureBlock BackpressureStrategy = iota
+	// BackpressureDropNewest Drop the value being published
+	BackpressureDropNewest
+	// BackpressureDropOldest Drop the oldest buffered value
+	BackpressureDropOldest
+	// BackpressureError Unsubscribe the subscriber and notify it by ErrSubscriptionBufferIsFull
+	BackpressureError
+)
 
 // PublisherDef Publisher inspired by Rx/NotificationCenter/PubSub
 type PublisherDef[T any] struct {
 	subscribers []*Subscription[T]
 	subscribeM  sync.Mutex
-	subOn       *HandlerDef
+	subOn       Scheduler
+
+	isDone bool
+	err    error
+
+	// history The history kept by Behavior/Replay/Async Publishers(nil for normal ones)
+	history publisherHistory[T]
 
-	origin *PublisherDef[T]
+	// Per-subscriber buffers(disabled if bufferCapacity <= 0)
+	bufferCapacity int
+	bufferStrategy BackpressureStrategy
+
+	// origins Attach to the upstreams(returning the detaching functions), attached again by the next subscriber after detaching
+	origins []func() func()
+	// detachOrigins Unsubscribe from the upstreams(when the last subscriber has gone or it's done)
+	detachOrigins []func()
+	// isDetached Detached since the last subscriber has gone
+	isDetached bool
+	// originGeneration Increased by every detaching(the late detaching functions of the previous attaching are called at once)
+	originGeneration int
 }
 
 // New New a Publisher
@@ -25,9 +65,13 @@ func PublisherNewGenerics[T any]() *PublisherDef[T] {
 
 // Map Map the Publisher in order to make a broadcasting chain
 func (publisherSelf *PublisherDef[T]) Map(fn func(T) T) *PublisherDef[T] {
-	next := PublisherNewGenerics[T]()
-	next.origin = publisherSelf
-	publisherSelf.Subscribe(Subscription[T]{
+	return PublisherMap(publisherSelf, fn)
+}
+
+// PublisherMap Map the Publisher to an another type in order to make a broadcasting chain(keeping its SubscribeOn Scheduler)
+func PublisherMap[T any, R any](publisherSelf *PublisherDef[T], fn func(T) R) *PublisherDef[R] {
+	next := publisherChainOf[T, R](publisherSelf)
+	publisherSubscribeOrigin(next, publisherSelf, Subscription[T]{
 		OnNext: func(in T) {
 			next.Publish(fn(in))
 		},
@@ -36,25 +80,188 @@ func (publisherSelf *PublisherDef[T]) Map(fn func(T) T) *PublisherDef[T] {
 	return next
 }
 
-// Subscribe Subscribe the Publisher by Subscription[T]
+// publisherChainOf New a chained Publisher of the upstream(keeping its SubscribeOn Scheduler & the kind of history)
+func publisherChainOf[T any, R any](upstream *PublisherDef[T]) *PublisherDef[R] {
+	next := PublisherNewGenerics[R]()
+	next.subOn = upstream.subOn
+	if upstream.history != nil {
+		next.history = newPublisherHistory[R](upstream.history.spec())
+	}
+
+	return next
+}
+
+// publisherSubscribeOrigin Subscribe the upstream for the chained Publisher,
+// errors/completion are forwarded by default(including panics of OnNext),
+// and the upstream would be unsubscribed when the last subscriber of the chained one has gone(subscribed again by the next subscriber, like refCount)
+func publisherSubscribeOrigin[T any, R any](next *PublisherDef[R], upstream *PublisherDef[T], sub Subscription[T]) {
+	if sub.OnError == nil {
+		sub.OnError = next.PublishError
+	}
+	if sub.OnComplete == nil {
+		sub.OnComplete = next.Complete
+	}
+	sub.isChained = true
+
+	next.addOrigin(func() func() {
+		s := upstream.Subscribe(sub)
+		return func() {
+			upstream.Unsubscribe(s)
+		}
+	})
+}
+
+// addOrigin Attach to an upstream by the function returning its detaching function(attached at once unless it's detached or done),
+// it's detached when the last subscriber has gone(attached again by the next subscriber) or it's done
+func (publisherSelf *PublisherDef[T]) addOrigin(attach func() func()) {
+	isAttaching := false
+	generation := 0
+	publisherSelf.doSubscribeSafe(func() {
+		if publisherSelf.isDone {
+			return
+		}
+		publisherSelf.origins = append(publisherSelf.origins, attach)
+		isAttaching = !publisherSelf.isDetached
+		generation = publisherSelf.originGeneration
+	})
+
+	if isAttaching {
+		publisherSelf.addDetachOrigin(generation, attach())
+	}
+}
+
+// addCleanup Add a function called when it's detached or done(e.g. stopping the timers)
+func (publisherSelf *PublisherDef[T]) addCleanup(cleanup func()) {
+	publisherSelf.addOrigin(func() func() {
+		return cleanup
+	})
+}
+
+// addDetachOrigin Add the detaching function of the attaching of the generation(called at once if it has been detached since then or it's done)
+func (publisherSelf *PublisherDef[T]) addDetachOrigin(generation int, detach func()) {
+	isStale := false
+	publisherSelf.doSubscribeSafe(func() {
+		isStale = publisherSelf.isDone || generation != publisherSelf.originGeneration
+		if !isStale {
+			publisherSelf.detachOrigins = append(publisherSelf.detachOrigins, detach)
+		}
+	})
+
+	if isStale {
+		detach()
+	}
+}
+
+// reattach Attach to the upstreams again for the new subscriber(if it has been detached)
+func (publisherSelf *PublisherDef[T]) reattach(origins []func() func(), generation int) {
+	for _, attach := range origins {
+		publisherSelf.addDetachOrigin(generation, attach())
+	}
+}
+
+func (publisherSelf *PublisherDef[T]) detach(detachOrigins []func()) {
+	for _, detach := range detachOrigins {
+		detach()
+	}
+}
+
+// Subscribe Subscribe the Publisher by Subscription[T](buffered if SetBackpressure() has been set)
 func (publisherSelf *PublisherDef[T]) Subscribe(sub Subscription[T]) *Subscription[T] {
+	var capacity int
+	var strategy BackpressureStrategy
+	publisherSelf.doSubscribeSafe(func() {
+		capacity = publisherSelf.bufferCapacity
+		strategy = publisherSelf.bufferStrategy
+	})
+
+	return publisherSelf.SubscribeWithBackpressure(sub, capacity, strategy)
+}
+
+// SubscribeWithBackpressure Subscribe the Publisher by Subscription[T] with its own buffer(unbuffered if capacity <= 0),
+// the subscriber would be called on the goroutine of the buffer
+func (publisherSelf *PublisherDef[T]) SubscribeWithBackpressure(sub Subscription[T], capacity int, strategy BackpressureStrategy) *Subscription[T] {
 	s := &sub
+	// Chained ones are relays, the chained Publisher buffers for its own subscribers
+	if capacity > 0 && !s.isChained {
+		s.buffer = newSubscriptionBuffer(capacity, strategy)
+	}
 
+	isDone := false
+	var err error
+	var replay []T
+	var origins []func() func()
+	originGeneration := 0
 	publisherSelf.doSubscribeSafe(func() {
-		publisherSelf.subscribers = append(publisherSelf.subscribers, s)
+		isDone = publisherSelf.isDone
+		err = publisherSelf.err
+		if publisherSelf.history != nil {
+			replay = publisherSelf.history.replay(isDone, err)
+		}
+		if !isDone {
+			if len(replay) > 0 {
+				// The concurrent live values wait for the replay
+				s.replayGate = &subscriptionReplayGate{isReplaying: true}
+			}
+			publisherSelf.subscribers = append(publisherSelf.subscribers, s)
+			if publisherSelf.isDetached {
+				publisherSelf.isDetached = false
+				origins = publisherSelf.origins
+				originGeneration = publisherSelf.originGeneration
+			}
+		}
 	})
+
+	// Replay the history(Behavior/Replay/Async Publishers)
+	for _, v := range replay {
+		value := v
+		publisherSelf.dispatchNow(s, false, func() {
+			s.doNext(value)
+		})
+	}
+	if s.replayGate != nil {
+		s.replayGate.release()
+	}
+	// Detached by the previous last subscriber, attach to the upstreams again
+	publisherSelf.reattach(origins, originGeneration)
+	// Late subscribers receive the terminal signal only
+	if isDone {
+		publisherSelf.dispatch(s, true, func() {
+			if err != nil {
+				s.doError(err)
+				return
+			}
+			s.doComplete()
+		})
+	}
 	return s
 }
 
-// SubscribeOn Subscribe the Publisher on the specific Handler
-func (publisherSelf *PublisherDef[T]) SubscribeOn(h *HandlerDef) *PublisherDef[T] {
+// SubscribeOn Subscribe the Publisher on the specific Scheduler(e.g. Handler)
+func (publisherSelf *PublisherDef[T]) SubscribeOn(h Scheduler) *PublisherDef[T] {
 	publisherSelf.subOn = h
 	return publisherSelf
 }
 
+// SetBackpressure Give every new subscriber its own buffer(disabled if capacity <= 0) with the overflow strategy,
+// thus a slow subscriber wouldn't stall the others
+func (publisherSelf *PublisherDef[T]) SetBackpressure(capacity int, strategy BackpressureStrategy) *PublisherDef[T] {
+	publisherSelf.doSubscribeSafe(func() {
+		publisherSelf.bufferCapacity = capacity
+		publisherSelf.bufferStrategy = strategy
+	})
+	return publisherSelf
+}
+
 // Unsubscribe Unsubscribe the publisher by the Subscription[T]
 func (publisherSelf *PublisherDef[T]) Unsubscribe(s *Subscription[T]) {
+	if publisherSelf.removeSubscription(s) && s.buffer != nil {
+		s.buffer.stop()
+	}
+}
+
+func (publisherSelf *PublisherDef[T]) removeSubscription(s *Subscription[T]) bool {
 	isAnyMatching := false
+	var detachOrigins []func()
 
 	publisherSelf.doSubscribeSafe(func() {
 		subscribers := publisherSelf.subscribers
@@ -66,34 +273,149 @@ func (publisherSelf *PublisherDef[T]) Unsubscribe(s *Subscription[T]) {
 				break
 			}
 		}
+
+		// The last one has gone, detach from the upstreams(attached again by the next subscriber)
+		if isAnyMatching && len(publisherSelf.subscribers) == 0 && len(publisherSelf.origins) > 0 && !publisherSelf.isDetached {
+			detachOrigins = publisherSelf.detachOrigins
+			publisherSelf.detachOrigins = nil
+			publisherSelf.isDetached = true
+			publisherSelf.originGeneration++
+		}
 	})
+	publisherSelf.detach(detachOrigins)
 
 	// Delete subscriptions recursively
 	if isAnyMatching {
-		publisherSelf.Unsubscribe(s)
+		publisherSelf.removeSubscription(s)
 	}
+
+	return isAnyMatching
 }
 
 // Publish Publish a value to its subscribers or next chains
 func (publisherSelf *PublisherDef[T]) Publish(result T) {
+	for _, s := range publisherSelf.recordAndGetSubscribers(result) {
+		if s.OnNext != nil {
+			sub := s
+			publisherSelf.dispatch(sub, false, func() {
+				sub.doNext(result)
+			})
+		}
+	}
+}
+
+// PublishError Publish an error to its subscribers or next chains, and terminate the Publisher
+func (publisherSelf *PublisherDef[T]) PublishError(err error) {
+	subscribers, _ := publisherSelf.terminate(err)
+	for _, s := range subscribers {
+		sub := s
+		publisherSelf.dispatch(sub, true, func() {
+			sub.doError(err)
+		})
+	}
+}
+
+// Complete Notify its subscribers or next chains the completion, and terminate the Publisher
+func (publisherSelf *PublisherDef[T]) Complete() {
+	subscribers, finalValues := publisherSelf.terminate(nil)
+	for _, s := range subscribers {
+		sub := s
+		// The final values of AsyncPublishers
+		for _, v := range finalValues {
+			value := v
+			publisherSelf.dispatch(sub, false, func() {
+				sub.doNext(value)
+			})
+		}
+		publisherSelf.dispatch(sub, true, func() {
+			sub.doComplete()
+		})
+	}
+}
+
+// IsDone Is the Publisher terminated(by Complete() or PublishError())
+func (publisherSelf *PublisherDef[T]) IsDone() bool {
+	isDone := false
+	publisherSelf.doSubscribeSafe(func() {
+		isDone = publisherSelf.isDone
+	})
+	return isDone
+}
+
+// recordAndGetSubscribers Record the value into the history and return the subscribers to be notified now
+func (publisherSelf *PublisherDef[T]) recordAndGetSubscribers(result T) []*Subscription[T] {
 	var subscribers []*Subscription[T]
 	publisherSelf.doSubscribeSafe(func() {
+		if publisherSelf.isDone {
+			return
+		}
+		if publisherSelf.history != nil && !publisherSelf.history.record(result) {
+			return
+		}
 		subscribers = publisherSelf.subscribers
 	})
+	return subscribers
+}
 
-	for _, s := range subscribers {
-		if s.OnNext != nil {
+// terminate Mark it done and return the subscribers(with the final values) to be notified(nothing if it has been done)
+func (publisherSelf *PublisherDef[T]) terminate(err error) ([]*Subscription[T], []T) {
+	var subscribers []*Subscription[T]
+	var finalValues []T
+	var detachOrigins []func()
+	publisherSelf.doSubscribeSafe(func() {
+		if publisherSelf.isDone {
+			return
+		}
+		publisherSelf.isDone = true
+		publisherSelf.err = err
+		subscribers = publisherSelf.subscribers
+		publisherSelf.subscribers = nil
+		detachOrigins = publisherSelf.detachOrigins
+		publisherSelf.detachOrigins = nil
+		publisherSelf.origins = nil
+		if err == nil && publisherSelf.history != nil {
+			finalValues = publisherSelf.history.final()
+		}
+	})
+	publisherSelf.detach(detachOrigins)
 
-			doSub := func() {
-				s.OnNext(result)
-			}
-			if publisherSelf.subOn != nil {
-				publisherSelf.subOn.Post(doSub)
-			} else {
-				doSub()
-			}
+	return subscribers, finalValues
+}
+
+func (publisherSelf *PublisherDef[T]) dispatch(s *Subscription[T], isTerminal bool, fn func()) {
+	if s.replayGate != nil && s.replayGate.hold(func() {
+		publisherSelf.dispatchNow(s, isTerminal, fn)
+	}) {
+		return
+	}
+
+	publisherSelf.dispatchNow(s, isTerminal, fn)
+}
+
+func (publisherSelf *PublisherDef[T]) dispatchNow(s *Subscription[T], isTerminal bool, fn func()) {
+	deliver := fn
+	if subOn := publisherSelf.subOn; subOn != nil && !s.isChained {
+		deliver = func() {
+			subOn.Schedule(fn)
 		}
 	}
+
+	if s.buffer == nil {
+		deliver()
+		return
+	}
+
+	if isTerminal {
+		s.buffer.putTerminal(deliver)
+		return
+	}
+	if s.buffer.offer(deliver) == ErrSubscriptionBufferIsFull {
+		// BackpressureError: the buffered ones are still delivered before the error
+		publisherSelf.removeSubscription(s)
+		s.buffer.putTerminal(func() {
+			s.doError(ErrSubscriptionBufferIsFull)
+		})
+	}
 }
 
 func (publisherSelf *PublisherDef[T]) doSubscribeSafe(fn func()) {
@@ -102,5 +424,154 @@ func (publisherSelf *PublisherDef[T]) doSubscribeSafe(fn func()) {
 	publisherSelf.subscribeM.Unlock()
 }
 
+// subscriptionReplayGate Hold the live deliveries of a new subscriber until its replay has been delivered
+type subscriptionReplayGate struct {
+	lock        sync.Mutex
+	isReplaying bool
+	pending     []func()
+}
+
+// hold Hold the delivery if it's still replaying, returns false if it should be delivered now
+func (gateSelf *subscriptionReplayGate) hold(deliver func()) bool {
+	gateSelf.lock.Lock()
+	defer gateSelf.lock.Unlock()
+
+	if !gateSelf.isReplaying {
+		return false
+	}
+	gateSelf.pending = append(gateSelf.pending, deliver)
+	return true
+}
+
+// release Deliver the held ones(including the ones held meanwhile) & then open the gate
+func (gateSelf *subscriptionReplayGate) release() {
+	for {
+		gateSelf.lock.Lock()
+		pending := gateSelf.pending
+		gateSelf.pending = nil
+		if len(pending) == 0 {
+			gateSelf.isReplaying = false
+			gateSelf.lock.Unlock()
+			return
+		}
+		gateSelf.lock.Unlock()
+
+		for _, deliver := range pending {
+			deliver()
+		}
+	}
+}
+
+// Backpressure
+
+// subscriptionBufferItem A buffered delivery of the subscriptionBuffer
+type subscriptionBufferItem struct {
+	fn func()
+}
+
+// subscriptionBuffer The per-subscriber buffer(a ChannelQueue consumed by its own goroutine)
+type subscriptionBuffer struct {
+	queue    ChannelQueue[*subscriptionBufferItem]
+	strategy BackpressureStrategy
+	// terminalCh The terminal delivery, kept out of the queue thus it's never dropped
+	terminalCh chan func()
+
+	droppedCount int64
+	isOverflowed AtomBool
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+}
+
+func newSubscriptionBuffer(capacity int, strategy BackpressureStrategy) *subscriptionBuffer {
+	buffer := &subscriptionBuffer{
+		queue:      NewChannelQueue[*subscriptionBufferItem](capacity),
+		strategy:   strategy,
+		terminalCh: make(chan func(), 1),
+		stopCh:     make(chan struct{}),
+	}
+	go buffer.run()
+
+	return buffer
+}
+
+func (bufferSelf *subscriptionBuffer) run() {
+	for {
+		select {
+		case item := <-bufferSelf.queue:
+			item.fn()
+		case fn := <-bufferSelf.terminalCh:
+			// Deliver the buffered values before the terminal one
+			for isDrained := false; !isDrained; {
+				select {
+				case item := <-bufferSelf.queue:
+					item.fn()
+				default:
+					isDrained = true
+				}
+			}
+			fn()
+			bufferSelf.stop()
+			return
+		case <-bufferSelf.stopCh:
+			return
+		}
+	}
+}
+
+// offer Buffer the delivery by the strategy
+func (bufferSelf *subscriptionBuffer) offer(fn func()) error {
+	if bufferSelf.isOverflowed.Get() {
+		atomic.AddInt64(&bufferSelf.droppedCount, 1)
+		return nil
+	}
+
+	item := &subscriptionBufferItem{fn: fn}
+	switch bufferSelf.strategy {
+	case BackpressureDropNewest:
+		if bufferSelf.queue.Offer(item) == ErrQueueIsFull {
+			atomic.AddInt64(&bufferSelf.droppedCount, 1)
+		}
+	case BackpressureDropOldest:
+		for bufferSelf.queue.Offer(item) == ErrQueueIsFull {
+			if _, err := bufferSelf.queue.Poll(); err == nil {
+				atomic.AddInt64(&bufferSelf.droppedCount, 1)
+			}
+		}
+	case BackpressureError:
+		if bufferSelf.queue.Offer(item) == ErrQueueIsFull {
+			bufferSelf.isOverflowed.Set(true)
+			atomic.AddInt64(&bufferSelf.droppedCount, 1)
+			return ErrSubscriptionBufferIsFull
+		}
+	default:
+		bufferSelf.put(item)
+	}
+
+	return nil
+}
+
+// putTerminal Terminal deliveries are never dropped(and never block the Publisher)
+func (bufferSelf *subscriptionBuffer) putTerminal(fn func()) {
+	select {
+	case bufferSelf.terminalCh <- fn:
+	default:
+		// There is only one terminal delivery
+	}
+}
+
+func (bufferSelf *subscriptionBuffer) put(item *subscriptionBufferItem) {
+	select {
+	case bufferSelf.queue <- item:
+	case <-bufferSelf.stopCh:
+	}
+}
+
+func (bufferSelf *subscriptionBuffer) stop() {
+	bufferSelf.stopOnce.Do(func() {
+		close(bufferSelf.stopCh)
+	})
+}
+
 // Publisher Publisher utils instance
 var Publisher PublisherDef[interface{}]
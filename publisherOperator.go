@@ -0,0 +1,602 @@
+package fpgo
+
+import (
+	"sync"
+	"time"
+)
+
+// Publisher Operators(inspired by Rx), every operator returns a chained Publisher,
+// which would unsubscribe from its upstream(s) when its last subscriber has gone(subscribing again for the next subscriber) or it's done.
+
+// PublisherFilter Filter values of the Publisher by the predicate
+func PublisherFilter[T any](publisherSelf *PublisherDef[T], fn Predicate[T]) *PublisherDef[T] {
+	next := publisherChainOf[T, T](publisherSelf)
+	publisherSubscribeOrigin(next, publisherSelf, Subscription[T]{
+		OnNext: func(in T) {
+			if fn(in) {
+				next.Publish(in)
+			}
+		},
+	})
+
+	return next
+}
+
+// PublisherTake Take the first count values of the Publisher, then complete
+func PublisherTake[T any](publisherSelf *PublisherDef[T], count int) *PublisherDef[T] {
+	next := publisherChainOf[T, T](publisherSelf)
+	if count <= 0 {
+		next.Complete()
+		return next
+	}
+
+	var lock sync.Mutex
+	taken := 0
+	publisherSubscribeOrigin(next, publisherSelf, Subscription[T]{
+		OnNext: func(in T) {
+			lock.Lock()
+			if taken >= count {
+				lock.Unlock()
+				return
+			}
+			taken++
+			isLast := taken == count
+			lock.Unlock()
+
+			next.Publish(in)
+			if isLast {
+				next.Complete()
+			}
+		},
+	})
+
+	return next
+}
+
+// PublisherSkip Skip the first count values of the Publisher
+func PublisherSkip[T any](publisherSelf *PublisherDef[T], count int) *PublisherDef[T] {
+	next := publisherChainOf[T, T](publisherSelf)
+
+	var lock sync.Mutex
+	skipped := 0
+	publisherSubscribeOrigin(next, publisherSelf, Subscription[T]{
+		OnNext: func(in T) {
+			lock.Lock()
+			if skipped < count {
+				skipped++
+				lock.Unlock()
+				return
+			}
+			lock.Unlock()
+
+			next.Publish(in)
+		},
+	})
+
+	return next
+}
+
+// PublisherScan Accumulate values of the Publisher by the reducer and publish every intermediate result
+func PublisherScan[T any, R any](publisherSelf *PublisherDef[T], fn ReducerFunctor[T, R], memo R) *PublisherDef[R] {
+	next := publisherChainOf[T, R](publisherSelf)
+
+	var lock sync.Mutex
+	publisherSubscribeOrigin(next, publisherSelf, Subscription[T]{
+		OnNext: func(in T) {
+			lock.Lock()
+			memo = fn(memo, in)
+			result := memo
+			lock.Unlock()
+
+			next.Publish(result)
+		},
+	})
+
+	return next
+}
+
+// PublisherDistinctUntilChanged Publish values only if they're different from the previous ones
+func PublisherDistinctUntilChanged[T comparable](publisherSelf *PublisherDef[T]) *PublisherDef[T] {
+	next := publisherChainOf[T, T](publisherSelf)
+
+	var lock sync.Mutex
+	var last T
+	hasLast := false
+	publisherSubscribeOrigin(next, publisherSelf, Subscription[T]{
+		OnNext: func(in T) {
+			lock.Lock()
+			if hasLast && last == in {
+				lock.Unlock()
+				return
+			}
+			last = in
+			hasLast = true
+			lock.Unlock()
+
+			next.Publish(in)
+		},
+	})
+
+	return next
+}
+
+// PublisherDebounce Publish the latest value only after the duration has passed without another value
+func PublisherDebounce[T any](publisherSelf *PublisherDef[T], duration time.Duration) *PublisherDef[T] {
+	next := publisherChainOf[T, T](publisherSelf)
+
+	var lock sync.Mutex
+	var timer *time.Timer
+	var latest T
+	hasLatest := false
+	// generation Avoid publishing by outdated timers
+	generation := 0
+
+	flush := func(gen int) {
+		lock.Lock()
+		if !hasLatest || (gen >= 0 && gen != generation) {
+			lock.Unlock()
+			return
+		}
+		result := latest
+		latest = *new(T)
+		hasLatest = false
+		lock.Unlock()
+
+		next.Publish(result)
+	}
+	stop := func() {
+		lock.Lock()
+		generation++
+		if timer != nil {
+			timer.Stop()
+			timer = nil
+		}
+		lock.Unlock()
+	}
+
+	publisherSubscribeOrigin(next, publisherSelf, Subscription[T]{
+		OnNext: func(in T) {
+			lock.Lock()
+			latest = in
+			hasLatest = true
+			generation++
+			gen := generation
+			if timer != nil {
+				timer.Stop()
+			}
+			timer = time.AfterFunc(duration, func() {
+				flush(gen)
+			})
+			lock.Unlock()
+		},
+		OnError: func(err error) {
+			stop()
+			next.PublishError(err)
+		},
+		OnComplete: func() {
+			stop()
+			// Flush the pending one
+			flush(-1)
+			next.Complete()
+		},
+	})
+	next.addCleanup(stop)
+
+	return next
+}
+
+// PublisherThrottle Publish the first value and then ignore the following ones for the duration
+func PublisherThrottle[T any](publisherSelf *PublisherDef[T], duration time.Duration) *PublisherDef[T] {
+	next := publisherChainOf[T, T](publisherSelf)
+
+	var lock sync.Mutex
+	var lastTime time.Time
+	publisherSubscribeOrigin(next, publisherSelf, Subscription[T]{
+		OnNext: func(in T) {
+			lock.Lock()
+			now := time.Now()
+			if !lastTime.IsZero() && now.Sub(lastTime) < duration {
+				lock.Unlock()
+				return
+			}
+			lastTime = now
+			lock.Unlock()
+
+			next.Publish(in)
+		},
+	})
+
+	return next
+}
+
+// PublisherBufferCount Buffer values of the Publisher and publish them every count values(the rest would be published when it completes)
+func PublisherBufferCount[T any](publisherSelf *PublisherDef[T], count int) *PublisherDef[[]T] {
+	next := publisherChainOf[T, []T](publisherSelf)
+	if count <= 0 {
+		count = 1
+	}
+
+	var lock sync.Mutex
+	var buffer []T
+	publisherSubscribeOrigin(next, publisherSelf, Subscription[T]{
+		OnNext: func(in T) {
+			lock.Lock()
+			buffer = append(buffer, in)
+			if len(buffer) < count {
+				lock.Unlock()
+				return
+			}
+			result := buffer
+			buffer = nil
+			lock.Unlock()
+
+			next.Publish(result)
+		},
+		OnComplete: func() {
+			lock.Lock()
+			result := buffer
+			buffer = nil
+			lock.Unlock()
+
+			if len(result) > 0 {
+				next.Publish(result)
+			}
+			next.Complete()
+		},
+	})
+
+	return next
+}
+
+// PublisherBufferTime Buffer values of the Publisher and publish them every time window(empty windows are skipped)
+func PublisherBufferTime[T any](publisherSelf *PublisherDef[T], duration time.Duration) *PublisherDef[[]T] {
+	next := publisherChainOf[T, []T](publisherSelf)
+
+	var lock sync.Mutex
+	var buffer []T
+	flush := func() {
+		lock.Lock()
+		result := buffer
+		buffer = nil
+		lock.Unlock()
+
+		if len(result) > 0 {
+			next.Publish(result)
+		}
+	}
+
+	// The ticker runs while it's attached to the upstream
+	var stopTicker func()
+	start := func() func() {
+		ticker := time.NewTicker(duration)
+		stopCh := make(chan struct{})
+		var stopOnce sync.Once
+		go func() {
+			for {
+				select {
+				case <-ticker.C:
+					flush()
+				case <-stopCh:
+					return
+				}
+			}
+		}()
+
+		stopThis := func() {
+			stopOnce.Do(func() {
+				ticker.Stop()
+				close(stopCh)
+			})
+		}
+		lock.Lock()
+		stopTicker = stopThis
+		lock.Unlock()
+		return stopThis
+	}
+	stop := func() {
+		lock.Lock()
+		stopCurrent := stopTicker
+		stopTicker = nil
+		lock.Unlock()
+
+		if stopCurrent != nil {
+			stopCurrent()
+		}
+	}
+	next.addOrigin(start)
+
+	publisherSubscribeOrigin(next, publisherSelf, Subscription[T]{
+		OnNext: func(in T) {
+			lock.Lock()
+			buffer = append(buffer, in)
+			lock.Unlock()
+		},
+		OnError: func(err error) {
+			stop()
+			next.PublishError(err)
+		},
+		OnComplete: func() {
+			stop()
+			flush()
+			next.Complete()
+		},
+	})
+
+	return next
+}
+
+// PublisherMerge Merge values of Publishers into one(completes when all of them complete),
+// the merged one keeps the SubscribeOn Scheduler & the kind of history of the first Publisher only
+func PublisherMerge[T any](publishers ...*PublisherDef[T]) *PublisherDef[T] {
+	if len(publishers) == 0 {
+		next := PublisherNewGenerics[T]()
+		next.Complete()
+		return next
+	}
+
+	next := publisherChainOf[T, T](publishers[0])
+
+	var lock sync.Mutex
+	remaining := len(publishers)
+	for _, upstream := range publishers {
+		publisherSubscribeOrigin(next, upstream, Subscription[T]{
+			OnNext: next.Publish,
+			OnComplete: func() {
+				lock.Lock()
+				remaining--
+				isAllDone := remaining == 0
+				lock.Unlock()
+
+				if isAllDone {
+					next.Complete()
+				}
+			},
+		})
+	}
+
+	return next
+}
+
+// PublisherCombineLatest Combine the latest values of both Publishers whenever anyone publishes(after both have published),
+// the combined one keeps the SubscribeOn Scheduler & the kind of history of publisherA only
+func PublisherCombineLatest[T any, U any, R any](publisherA *PublisherDef[T], publisherB *PublisherDef[U], fn func(T, U) R) *PublisherDef[R] {
+	next := publisherChainOf[T, R](publisherA)
+
+	var lock sync.Mutex
+	var latestA T
+	var latestB U
+	hasA, hasB := false, false
+	isDoneA, isDoneB := false, false
+
+	publishLatest := func() {
+		lock.Lock()
+		if !(hasA && hasB) {
+			lock.Unlock()
+			return
+		}
+		a, b := latestA, latestB
+		lock.Unlock()
+
+		next.Publish(fn(a, b))
+	}
+	// Complete if both are done, or anyone is done without any values
+	completeIfNeeded := func() {
+		lock.Lock()
+		isDone := (isDoneA && isDoneB) || (isDoneA && !hasA) || (isDoneB && !hasB)
+		lock.Unlock()
+
+		if isDone {
+			next.Complete()
+		}
+	}
+
+	publisherSubscribeOrigin(next, publisherA, Subscription[T]{
+		OnNext: func(in T) {
+			lock.Lock()
+			latestA = in
+			hasA = true
+			lock.Unlock()
+
+			publishLatest()
+		},
+		OnComplete: func() {
+			lock.Lock()
+			isDoneA = true
+			lock.Unlock()
+
+			completeIfNeeded()
+		},
+	})
+	publisherSubscribeOrigin(next, publisherB, Subscription[U]{
+		OnNext: func(in U) {
+			lock.Lock()
+			latestB = in
+			hasB = true
+			lock.Unlock()
+
+			publishLatest()
+		},
+		OnComplete: func() {
+			lock.Lock()
+			isDoneB = true
+			lock.Unlock()
+
+			completeIfNeeded()
+		},
+	})
+
+	return next
+}
+
+// PublisherZip Combine values of both Publishers pairwise in order(completes when anyone is done and has no more values to pair),
+// the zipped one keeps the SubscribeOn Scheduler & the kind of history of publisherA only
+func PublisherZip[T any, U any, R any](publisherA *PublisherDef[T], publisherB *PublisherDef[U], fn func(T, U) R) *PublisherDef[R] {
+	next := publisherChainOf[T, R](publisherA)
+
+	var lock sync.Mutex
+	queueA := NewLinkedListQueue[T]()
+	queueB := NewLinkedListQueue[U]()
+	isDoneA, isDoneB := false, false
+
+	// Complete if anyone is done and there's nothing left to pair
+	isCompleted := func() bool {
+		return (isDoneA && queueA.Count() == 0) || (isDoneB && queueB.Count() == 0)
+	}
+
+	publisherSubscribeOrigin(next, publisherA, Subscription[T]{
+		OnNext: func(in T) {
+			lock.Lock()
+			b, err := queueB.Poll()
+			if err != nil {
+				queueA.Offer(in)
+				lock.Unlock()
+				return
+			}
+			isDone := isCompleted()
+			lock.Unlock()
+
+			next.Publish(fn(in, b))
+			if isDone {
+				next.Complete()
+			}
+		},
+		OnComplete: func() {
+			lock.Lock()
+			isDoneA = true
+			isDone := isCompleted()
+			lock.Unlock()
+
+			if isDone {
+				next.Complete()
+			}
+		},
+	})
+	publisherSubscribeOrigin(next, publisherB, Subscription[U]{
+		OnNext: func(in U) {
+			lock.Lock()
+			a, err := queueA.Poll()
+			if err != nil {
+				queueB.Offer(in)
+				lock.Unlock()
+				return
+			}
+			isDone := isCompleted()
+			lock.Unlock()
+
+			next.Publish(fn(a, in))
+			if isDone {
+				next.Complete()
+			}
+		},
+		OnComplete: func() {
+			lock.Lock()
+			isDoneB = true
+			isDone := isCompleted()
+			lock.Unlock()
+
+			if isDone {
+				next.Complete()
+			}
+		},
+	})
+
+	return next
+}
+
+// PublisherSwitchMap Map every value to an inner Publisher and publish values of the latest inner one only
+// (the previous inner one would be unsubscribed)
+func PublisherSwitchMap[T any, R any](publisherSelf *PublisherDef[T], fn func(T) *PublisherDef[R]) *PublisherDef[R] {
+	next := publisherChainOf[T, R](publisherSelf)
+
+	var lock sync.Mutex
+	var detachInner func()
+	// generation Ignore values of outdated inner ones
+	generation := 0
+	isOuterDone := false
+	isInnerDone := true
+
+	stopInner := func() {
+		lock.Lock()
+		generation++
+		detach := detachInner
+		detachInner = nil
+		isInnerDone = true
+		lock.Unlock()
+
+		if detach != nil {
+			detach()
+		}
+	}
+	switchTo := func(inner *PublisherDef[R]) {
+		stopInner()
+
+		lock.Lock()
+		gen := generation
+		isInnerDone = false
+		lock.Unlock()
+		isCurrent := func() bool {
+			lock.Lock()
+			defer lock.Unlock()
+			return gen == generation
+		}
+
+		s := inner.Subscribe(Subscription[R]{
+			OnNext: func(in R) {
+				if isCurrent() {
+					next.Publish(in)
+				}
+			},
+			OnError: func(err error) {
+				if isCurrent() {
+					next.PublishError(err)
+				}
+			},
+			OnComplete: func() {
+				lock.Lock()
+				if gen != generation {
+					lock.Unlock()
+					return
+				}
+				isInnerDone = true
+				isDone := isOuterDone
+				lock.Unlock()
+
+				if isDone {
+					next.Complete()
+				}
+			},
+
+			isChained: true,
+		})
+
+		lock.Lock()
+		if gen != generation {
+			lock.Unlock()
+			inner.Unsubscribe(s)
+			return
+		}
+		detachInner = func() {
+			inner.Unsubscribe(s)
+		}
+		lock.Unlock()
+	}
+
+	publisherSubscribeOrigin(next, publisherSelf, Subscription[T]{
+		OnNext: func(in T) {
+			switchTo(fn(in))
+		},
+		OnComplete: func() {
+			lock.Lock()
+			isOuterDone = true
+			isDone := isInnerDone
+			lock.Unlock()
+
+			if isDone {
+				next.Complete()
+			}
+		},
+	})
+	next.addCleanup(stopInner)
+
+	return next
+}
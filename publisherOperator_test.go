@@ -0,0 +1,220 @@
+package fpgo
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func collectPublisher[T any](p *PublisherDef[T]) (*[]T, *bool, *error, *Subscription[T]) {
+	var lock sync.Mutex
+	actual := []T{}
+	isCompleted := false
+	var actualErr error
+	s := p.Subscribe(Subscription[T]{
+		OnNext: func(in T) {
+			lock.Lock()
+			actual = append(actual, in)
+			lock.Unlock()
+		},
+		OnError: func(err error) {
+			actualErr = err
+		},
+		OnComplete: func() {
+			isCompleted = true
+		},
+	})
+	return &actual, &isCompleted, &actualErr, s
+}
+
+func TestPublisherFilterTakeSkip(t *testing.T) {
+	p := PublisherNewGenerics[int]()
+	filtered := PublisherFilter(p, func(in int) bool {
+		return in%2 == 0
+	})
+	actual, _, _, _ := collectPublisher(filtered)
+	taken := PublisherTake(p, 2)
+	actualTaken, isTakenCompleted, _, _ := collectPublisher(taken)
+	skipped := PublisherSkip(p, 2)
+	actualSkipped, _, _, _ := collectPublisher(skipped)
+
+	for i := 1; i <= 5; i++ {
+		p.Publish(i)
+	}
+	assert.Equal(t, []int{2, 4}, *actual)
+	assert.Equal(t, []int{1, 2}, *actualTaken)
+	assert.True(t, *isTakenCompleted)
+	assert.Equal(t, []int{3, 4, 5}, *actualSkipped)
+	// Take unsubscribed from the upstream when it's done
+	assert.Equal(t, 2, len(p.subscribers))
+}
+
+func TestPublisherScanAndDistinctUntilChanged(t *testing.T) {
+	p := PublisherNewGenerics[int]()
+	scanned := PublisherScan(p, func(memo string, in int) string {
+		return memo + strconv.Itoa(in)
+	}, "")
+	actualScanned, _, _, _ := collectPublisher(scanned)
+	distinct := PublisherDistinctUntilChanged(p)
+	actualDistinct, _, _, _ := collectPublisher(distinct)
+
+	for _, v := range []int{1, 1, 2, 2, 1} {
+		p.Publish(v)
+	}
+	assert.Equal(t, []string{"1", "11", "112", "1122", "11221"}, *actualScanned)
+	assert.Equal(t, []int{1, 2, 1}, *actualDistinct)
+}
+
+func TestPublisherDebounceAndThrottle(t *testing.T) {
+	p := PublisherNewGenerics[int]()
+	debounced := PublisherDebounce(p, 20*time.Millisecond)
+	actualDebounced, isCompleted, _, _ := collectPublisher(debounced)
+	throttled := PublisherThrottle(p, 50*time.Millisecond)
+	actualThrottled, _, _, _ := collectPublisher(throttled)
+
+	p.Publish(1)
+	p.Publish(2)
+	p.Publish(3)
+	time.Sleep(60 * time.Millisecond)
+	p.Publish(4)
+	p.Publish(5)
+	p.Complete()
+
+	assert.Equal(t, []int{3, 5}, *actualDebounced)
+	assert.True(t, *isCompleted)
+	assert.Equal(t, []int{1, 4}, *actualThrottled)
+}
+
+func TestPublisherBuffer(t *testing.T) {
+	p := PublisherNewGenerics[int]()
+	bufferedByCount := PublisherBufferCount(p, 2)
+	actualByCount, isCompleted, _, _ := collectPublisher(bufferedByCount)
+	bufferedByTime := PublisherBufferTime(p, 20*time.Millisecond)
+	actualByTime, _, _, _ := collectPublisher(bufferedByTime)
+
+	p.Publish(1)
+	p.Publish(2)
+	p.Publish(3)
+	time.Sleep(30 * time.Millisecond)
+	p.Publish(4)
+	p.Publish(5)
+	p.Complete()
+
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}, {5}}, *actualByCount)
+	assert.True(t, *isCompleted)
+	assert.Equal(t, [][]int{{1, 2, 3}, {4, 5}}, *actualByTime)
+}
+
+func TestPublisherMergeCombineLatestZip(t *testing.T) {
+	p1 := PublisherNewGenerics[int]()
+	p2 := PublisherNewGenerics[int]()
+	p3 := PublisherNewGenerics[string]()
+
+	merged := PublisherMerge(p1, p2)
+	actualMerged, isMergedCompleted, _, _ := collectPublisher(merged)
+	combined := PublisherCombineLatest(p1, p3, func(a int, b string) string {
+		return strconv.Itoa(a) + b
+	})
+	actualCombined, _, _, _ := collectPublisher(combined)
+	zipped := PublisherZip(p1, p3, func(a int, b string) string {
+		return strconv.Itoa(a) + b
+	})
+	actualZipped, isZippedCompleted, _, _ := collectPublisher(zipped)
+
+	p1.Publish(1)
+	p2.Publish(10)
+	p1.Publish(2)
+	p3.Publish("a")
+	p3.Publish("b")
+	p1.Publish(3)
+	p3.Publish("c")
+	p1.Complete()
+	assert.False(t, *isMergedCompleted)
+	p2.Complete()
+	assert.True(t, *isMergedCompleted)
+	assert.Equal(t, []int{1, 10, 2, 3}, *actualMerged)
+	assert.Equal(t, []string{"2a", "2b", "3b", "3c"}, *actualCombined)
+	assert.Equal(t, []string{"1a", "2b", "3c"}, *actualZipped)
+	assert.True(t, *isZippedCompleted)
+}
+
+func TestPublisherSwitchMap(t *testing.T) {
+	p := PublisherNewGenerics[int]()
+	inners := map[int]*PublisherDef[string]{
+		1: PublisherNewGenerics[string](),
+		2: PublisherNewGenerics[string](),
+	}
+	switched := PublisherSwitchMap(p, func(in int) *PublisherDef[string] {
+		return inners[in]
+	})
+	actual, isCompleted, actualErr, _ := collectPublisher(switched)
+
+	p.Publish(1)
+	inners[1].Publish("1a")
+	p.Publish(2)
+	// Outdated
+	inners[1].Publish("1b")
+	inners[2].Publish("2a")
+	assert.Equal(t, []string{"1a", "2a"}, *actual)
+	assert.Equal(t, 0, len(inners[1].subscribers))
+
+	// Completes after both the outer & the inner complete
+	p.Complete()
+	assert.False(t, *isCompleted)
+	inners[2].Complete()
+	assert.True(t, *isCompleted)
+	assert.NoError(t, *actualErr)
+
+	// Errors of the inner one
+	errExpected := errors.New("inner error")
+	p = PublisherNewGenerics[int]()
+	inner := PublisherNewGenerics[string]()
+	switched = PublisherSwitchMap(p, func(in int) *PublisherDef[string] {
+		return inner
+	})
+	_, _, actualErr, _ = collectPublisher(switched)
+	p.Publish(1)
+	inner.PublishError(errExpected)
+	assert.Equal(t, errExpected, *actualErr)
+	assert.Equal(t, 0, len(p.subscribers))
+}
+
+func TestPublisherOperatorUnsubscribeUpstream(t *testing.T) {
+	p := PublisherNewGenerics[int]()
+	p2 := PublisherNewGenerics[int]()
+	filtered := PublisherFilter(PublisherMap(p, func(in int) int {
+		return in + 1
+	}), func(in int) bool {
+		return true
+	})
+	merged := PublisherMerge(p, p2)
+	assert.Equal(t, 2, len(p.subscribers))
+	assert.Equal(t, 1, len(p2.subscribers))
+
+	_, _, _, s1 := collectPublisher(filtered)
+	_, _, _, s2 := collectPublisher(filtered)
+	filtered.Unsubscribe(s1)
+	// Still subscribed by s2
+	assert.Equal(t, 2, len(p.subscribers))
+	filtered.Unsubscribe(s2)
+	// The last one has gone: unsubscribed recursively
+	assert.Equal(t, 1, len(p.subscribers))
+	// & then subscribed again by the next subscriber
+	assert.False(t, filtered.IsDone())
+	actualLate, isCompleted, _, s4 := collectPublisher(filtered)
+	assert.Equal(t, 2, len(p.subscribers))
+	p.Publish(1)
+	assert.Equal(t, []int{2}, *actualLate)
+	assert.False(t, *isCompleted)
+	filtered.Unsubscribe(s4)
+	assert.Equal(t, 1, len(p.subscribers))
+
+	_, _, _, s3 := collectPublisher(merged)
+	merged.Unsubscribe(s3)
+	assert.Equal(t, 0, len(p.subscribers))
+	assert.Equal(t, 0, len(p2.subscribers))
+}
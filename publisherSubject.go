@@ -0,0 +1,197 @@
+package fpgo
+
+import "time"
+
+// publisherHistory The history kept by Behavior/Replay/Async Publishers(guarded by the subscribeM of the Publisher)
+type publisherHistory[T any] interface {
+	// record Record the published value, returns false if it shouldn't be delivered right now
+	record(value T) bool
+	// replay The values delivered to a new subscriber
+	replay(isDone bool, err error) []T
+	// final The values delivered before the completion
+	final() []T
+	// spec The spec to make the same kind of history for chained Publishers
+	spec() publisherHistorySpec
+}
+
+type publisherHistoryKind int
+
+const (
+	publisherHistoryBehavior publisherHistoryKind = iota
+	publisherHistoryReplay
+	publisherHistoryAsync
+)
+
+// publisherHistorySpec The type-erased spec of a publisherHistory
+type publisherHistorySpec struct {
+	kind   publisherHistoryKind
+	size   int
+	window time.Duration
+}
+
+// newPublisherHistory New the same kind of history(chained BehaviorPublishers start without the latest value)
+func newPublisherHistory[T any](spec publisherHistorySpec) publisherHistory[T] {
+	switch spec.kind {
+	case publisherHistoryReplay:
+		return &replayHistory[T]{size: spec.size, window: spec.window}
+	case publisherHistoryAsync:
+		return &asyncHistory[T]{}
+	default:
+		return &behaviorHistory[T]{}
+	}
+}
+
+// BehaviorPublisher
+
+type behaviorHistory[T any] struct {
+	latest    T
+	hasLatest bool
+}
+
+func (historySelf *behaviorHistory[T]) record(value T) bool {
+	historySelf.latest = value
+	historySelf.hasLatest = true
+	return true
+}
+
+func (historySelf *behaviorHistory[T]) replay(isDone bool, err error) []T {
+	if isDone || !historySelf.hasLatest {
+		return nil
+	}
+
+	return []T{historySelf.latest}
+}
+
+func (historySelf *behaviorHistory[T]) final() []T {
+	return nil
+}
+
+func (historySelf *behaviorHistory[T]) spec() publisherHistorySpec {
+	return publisherHistorySpec{kind: publisherHistoryBehavior}
+}
+
+// NewBehavior New a BehaviorPublisher(new subscribers receive the latest value, starting with the initial one)
+func (publisherSelf *PublisherDef[T]) NewBehavior(initial interface{}) *PublisherDef[interface{}] {
+	return PublisherBehaviorNewGenerics(initial)
+}
+
+// PublisherBehaviorNewGenerics New a BehaviorPublisher(new subscribers receive the latest value, starting with the initial one)
+func PublisherBehaviorNewGenerics[T any](initial T) *PublisherDef[T] {
+	p := PublisherNewGenerics[T]()
+	p.history = &behaviorHistory[T]{latest: initial, hasLatest: true}
+
+	return p
+}
+
+// ReplayPublisher
+
+type replayHistoryItem[T any] struct {
+	value T
+	time  time.Time
+}
+
+type replayHistory[T any] struct {
+	size   int
+	window time.Duration
+
+	items []replayHistoryItem[T]
+}
+
+func (historySelf *replayHistory[T]) trim() {
+	items := historySelf.items
+	if historySelf.size > 0 && len(items) > historySelf.size {
+		items = items[len(items)-historySelf.size:]
+	}
+	if historySelf.window > 0 {
+		deadline := time.Now().Add(-historySelf.window)
+		i := 0
+		for i < len(items) && items[i].time.Before(deadline) {
+			i++
+		}
+		items = items[i:]
+	}
+	historySelf.items = items
+}
+
+func (historySelf *replayHistory[T]) record(value T) bool {
+	historySelf.items = append(historySelf.items, replayHistoryItem[T]{value: value, time: time.Now()})
+	historySelf.trim()
+	return true
+}
+
+func (historySelf *replayHistory[T]) replay(isDone bool, err error) []T {
+	historySelf.trim()
+
+	values := make([]T, len(historySelf.items))
+	for i, item := range historySelf.items {
+		values[i] = item.value
+	}
+	return values
+}
+
+func (historySelf *replayHistory[T]) final() []T {
+	return nil
+}
+
+func (historySelf *replayHistory[T]) spec() publisherHistorySpec {
+	return publisherHistorySpec{kind: publisherHistoryReplay, size: historySelf.size, window: historySelf.window}
+}
+
+// NewReplay New a ReplayPublisher(new subscribers receive the last size values(unlimited if size <= 0) within the window(unlimited if window <= 0))
+func (publisherSelf *PublisherDef[T]) NewReplay(size int, window time.Duration) *PublisherDef[interface{}] {
+	return PublisherReplayNewGenerics[interface{}](size, window)
+}
+
+// PublisherReplayNewGenerics New a ReplayPublisher(new subscribers receive the last size values(unlimited if size <= 0) within the window(unlimited if window <= 0))
+func PublisherReplayNewGenerics[T any](size int, window time.Duration) *PublisherDef[T] {
+	p := PublisherNewGenerics[T]()
+	p.history = &replayHistory[T]{size: size, window: window}
+
+	return p
+}
+
+// AsyncPublisher
+
+type asyncHistory[T any] struct {
+	last    T
+	hasLast bool
+}
+
+func (historySelf *asyncHistory[T]) record(value T) bool {
+	historySelf.last = value
+	historySelf.hasLast = true
+	return false
+}
+
+func (historySelf *asyncHistory[T]) replay(isDone bool, err error) []T {
+	if isDone && err == nil {
+		return historySelf.final()
+	}
+
+	return nil
+}
+
+func (historySelf *asyncHistory[T]) final() []T {
+	if !historySelf.hasLast {
+		return nil
+	}
+
+	return []T{historySelf.last}
+}
+
+func (historySelf *asyncHistory[T]) spec() publisherHistorySpec {
+	return publisherHistorySpec{kind: publisherHistoryAsync}
+}
+
+// NewAsync New an AsyncPublisher(subscribers receive only the final value when it completes)
+func (publisherSelf *PublisherDef[T]) NewAsync() *PublisherDef[interface{}] {
+	return PublisherAsyncNewGenerics[interface{}]()
+}
+
+// PublisherAsyncNewGenerics New an AsyncPublisher(subscribers receive only the final value when it completes)
+func PublisherAsyncNewGenerics[T any]() *PublisherDef[T] {
+	p := PublisherNewGenerics[T]()
+	p.history = &asyncHistory[T]{}
+
+	return p
+}
@@ -0,0 +1,120 @@
+package fpgo
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublisherBehavior(t *testing.T) {
+	p := PublisherBehaviorNewGenerics(0)
+
+	actual, _, _, s := collectPublisher(p)
+	assert.Equal(t, []int{0}, *actual)
+	p.Publish(1)
+	p.Publish(2)
+	assert.Equal(t, []int{0, 1, 2}, *actual)
+
+	// Late subscribers receive the latest one
+	actualLate, _, _, _ := collectPublisher(p)
+	assert.Equal(t, []int{2}, *actualLate)
+
+	// Unsubscribe
+	p.Unsubscribe(s)
+	p.Publish(3)
+	assert.Equal(t, []int{0, 1, 2}, *actual)
+	assert.Equal(t, []int{2, 3}, *actualLate)
+
+	// Completed: nothing to replay
+	p.Complete()
+	actualDone, isCompleted, _, _ := collectPublisher(p)
+	assert.Equal(t, []int{}, *actualDone)
+	assert.True(t, *isCompleted)
+
+	// Operators
+	p = PublisherBehaviorNewGenerics(1)
+	actualMapped, _, _, _ := collectPublisher(PublisherMap(p, func(in int) int {
+		return in * 10
+	}))
+	p.Publish(2)
+	assert.Equal(t, []int{10, 20}, *actualMapped)
+
+	// The live values published during the replay are delivered after it
+	p = PublisherBehaviorNewGenerics(1)
+	actualOrdered := []int{}
+	p.Subscribe(Subscription[int]{
+		OnNext: func(in int) {
+			if in == 1 {
+				published := make(chan bool)
+				go func() {
+					p.Publish(2)
+					close(published)
+				}()
+				<-published
+			}
+			actualOrdered = append(actualOrdered, in)
+		},
+	})
+	assert.Equal(t, []int{1, 2}, actualOrdered)
+
+	// Utils instance
+	actualInterface, _, _, _ := collectPublisher(Publisher.NewBehavior(1))
+	assert.Equal(t, []interface{}{1}, *actualInterface)
+}
+
+func TestPublisherReplay(t *testing.T) {
+	// By size
+	p := PublisherReplayNewGenerics[int](2, 0)
+	p.Publish(1)
+	p.Publish(2)
+	p.Publish(3)
+	actual, _, _, _ := collectPublisher(p)
+	assert.Equal(t, []int{2, 3}, *actual)
+	p.Publish(4)
+	assert.Equal(t, []int{2, 3, 4}, *actual)
+
+	// Completed: replay values then the completion
+	p.Complete()
+	actualDone, isCompleted, _, _ := collectPublisher(p)
+	assert.Equal(t, []int{3, 4}, *actualDone)
+	assert.True(t, *isCompleted)
+
+	// By the time window
+	p = PublisherReplayNewGenerics[int](0, 20*time.Millisecond)
+	p.Publish(1)
+	time.Sleep(40 * time.Millisecond)
+	p.Publish(2)
+	p.Publish(3)
+	actual, _, _, _ = collectPublisher(p)
+	assert.Equal(t, []int{2, 3}, *actual)
+}
+
+func TestPublisherAsync(t *testing.T) {
+	p := PublisherAsyncNewGenerics[int]()
+	actual, isCompleted, _, _ := collectPublisher(p)
+	p.Publish(1)
+	p.Publish(2)
+	assert.Equal(t, []int{}, *actual)
+	p.Complete()
+	assert.Equal(t, []int{2}, *actual)
+	assert.True(t, *isCompleted)
+
+	// Late subscribers
+	actualLate, isLateCompleted, _, _ := collectPublisher(p)
+	assert.Equal(t, []int{2}, *actualLate)
+	assert.True(t, *isLateCompleted)
+
+	// Errors: no values
+	errExpected := errors.New("async error")
+	p = PublisherAsyncNewGenerics[int]()
+	actual, _, actualErr, _ := collectPublisher(p)
+	p.Publish(1)
+	p.PublishError(errExpected)
+	assert.Equal(t, []int{}, *actual)
+	assert.Equal(t, errExpected, *actualErr)
+	actualLate, _, actualLateErr, _ := collectPublisher(p)
+	assert.Equal(t, []int{}, *actualLate)
+	assert.Equal(t, errExpected, *actualLateErr)
+}
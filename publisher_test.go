@@ -1,7 +1,12 @@
 package fpgo
 
 import (
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -65,3 +70,304 @@ func TestPublisher(t *testing.T) {
 	p.Publish((1))
 	assert.Equal(t, expected, actual)
 }
+
+func TestPublisherErrorAndComplete(t *testing.T) {
+	var actual int
+	var actualErr error
+	var isCompleted bool
+	subscription := Subscription[int]{
+		OnNext: func(in int) {
+			actual = in
+		},
+		OnError: func(err error) {
+			actualErr = err
+		},
+		OnComplete: func() {
+			isCompleted = true
+		},
+	}
+
+	// Complete
+	p := PublisherNewGenerics[int]()
+	p.Subscribe(subscription)
+	p.Publish(1)
+	assert.Equal(t, 1, actual)
+	p.Complete()
+	assert.True(t, isCompleted)
+	assert.True(t, p.IsDone())
+	// Ignored after the completion
+	p.Publish(2)
+	assert.Equal(t, 1, actual)
+	p.PublishError(errors.New("ignored"))
+	assert.NoError(t, actualErr)
+	// Late subscribers
+	isCompleted = false
+	p.Subscribe(subscription)
+	assert.True(t, isCompleted)
+
+	// Errors through Map chains
+	actual, actualErr, isCompleted = 0, nil, false
+	errExpected := errors.New("publisher error")
+	p = PublisherNewGenerics[int]()
+	p.Map(func(in int) int {
+		return in + 1
+	}).Subscribe(subscription)
+	p.Publish(1)
+	assert.Equal(t, 2, actual)
+	p.PublishError(errExpected)
+	assert.Equal(t, errExpected, actualErr)
+	assert.False(t, isCompleted)
+
+	// Completion through Map chains
+	actual, actualErr, isCompleted = 0, nil, false
+	p = PublisherNewGenerics[int]()
+	p.Map(func(in int) int {
+		return in + 1
+	}).Subscribe(subscription)
+	p.Complete()
+	assert.True(t, isCompleted)
+
+	// Panics inside Map
+	actual, actualErr, isCompleted = 0, nil, false
+	p = PublisherNewGenerics[int]()
+	p2 := p.Map(func(in int) int {
+		if in < 0 {
+			panic("negative")
+		}
+		return in
+	})
+	p2.Subscribe(subscription)
+	p.Publish(-1)
+	assert.Equal(t, 0, actual)
+	assert.Equal(t, "negative", actualErr.(*PanicError).Value)
+	assert.True(t, p2.IsDone())
+
+	// Panics inside OnNext without OnError keep panicking
+	p = PublisherNewGenerics[int]()
+	p.Subscribe(Subscription[int]{
+		OnNext: func(in int) {
+			panic("no OnError")
+		},
+	})
+	assert.Panics(t, func() {
+		p.Publish(1)
+	})
+}
+
+func TestPublisherMap(t *testing.T) {
+	var actual string
+	var s *Subscription[string]
+
+	p := PublisherNewGenerics[int]()
+	p2 := PublisherMap(p, func(in int) string {
+		return strconv.Itoa(in + 1)
+	})
+	assert.Equal(t, 1, len(p.subscribers))
+	s = p2.Subscribe(Subscription[string]{
+		OnNext: func(in string) {
+			actual = in
+		},
+	})
+	p.Publish(1)
+	assert.Equal(t, "2", actual)
+	actual = ""
+	p2.Unsubscribe(s)
+	// Detached from the upstream
+	assert.Equal(t, 0, len(p.subscribers))
+	p.Publish(1)
+	assert.Equal(t, "", actual)
+	// Subscribed again by the next subscriber
+	actualAgain := []string{}
+	s = p2.Subscribe(Subscription[string]{
+		OnNext: func(in string) {
+			actualAgain = append(actualAgain, in)
+		},
+	})
+	assert.Equal(t, 1, len(p.subscribers))
+	p.Publish(2)
+	p.Publish(3)
+	assert.Equal(t, []string{"3", "4"}, actualAgain)
+	assert.False(t, p2.IsDone())
+	p2.Unsubscribe(s)
+	assert.Equal(t, 0, len(p.subscribers))
+
+	// SubscribeOn Handler is kept
+	h := Handler.New()
+	defer h.Close()
+	var wg sync.WaitGroup
+	p = PublisherNewGenerics[int]()
+	p.SubscribeOn(h)
+	p2 = PublisherMap(PublisherMap(p, func(in int) int {
+		return in * 2
+	}), func(in int) string {
+		return strconv.Itoa(in)
+	})
+	assert.Equal(t, h, p2.subOn)
+	p2.Subscribe(Subscription[string]{
+		OnNext: func(in string) {
+			actual = in
+			wg.Done()
+		},
+	})
+	wg.Add(1)
+	p.Publish(3)
+	wg.Wait()
+	assert.Equal(t, "6", actual)
+}
+
+func TestPublisherBackpressure(t *testing.T) {
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	release := make(chan struct{})
+	slowSubscription := func(actual *[]int, isCompleted *bool, actualErr *error) Subscription[int] {
+		return Subscription[int]{
+			OnNext: func(in int) {
+				<-release
+				lock.Lock()
+				*actual = append(*actual, in)
+				lock.Unlock()
+			},
+			OnError: func(err error) {
+				*actualErr = err
+				wg.Done()
+			},
+			OnComplete: func() {
+				*isCompleted = true
+				wg.Done()
+			},
+		}
+	}
+
+	// A slow subscriber doesn't stall the Publisher & the others
+	p := PublisherNewGenerics[int]().SetBackpressure(2, BackpressureDropNewest)
+	var actualDropNewest, actualFast []int
+	var isCompleted bool
+	var actualErr error
+	sDropNewest := p.Subscribe(slowSubscription(&actualDropNewest, &isCompleted, &actualErr))
+	p.SubscribeWithBackpressure(Subscription[int]{
+		OnNext: func(in int) {
+			lock.Lock()
+			actualFast = append(actualFast, in)
+			lock.Unlock()
+		},
+	}, 10, BackpressureDropNewest)
+	p.Publish(1)
+	time.Sleep(5 * time.Millisecond)
+	for i := 2; i <= 5; i++ {
+		p.Publish(i)
+	}
+	assert.Eventually(t, func() bool {
+		lock.Lock()
+		defer lock.Unlock()
+		return len(actualFast) == 5
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, actualFast)
+	wg.Add(1)
+	p.Complete()
+	close(release)
+	wg.Wait()
+	// 1 is being handled, 2 & 3 are buffered
+	assert.Equal(t, []int{1, 2, 3}, actualDropNewest)
+	assert.Equal(t, int64(2), sDropNewest.DroppedCount())
+	assert.True(t, isCompleted)
+
+	// DropOldest
+	release = make(chan struct{})
+	p = PublisherNewGenerics[int]()
+	var actualDropOldest []int
+	isCompleted = false
+	sDropOldest := p.SubscribeWithBackpressure(slowSubscription(&actualDropOldest, &isCompleted, &actualErr), 2, BackpressureDropOldest)
+	p.Publish(1)
+	time.Sleep(5 * time.Millisecond)
+	for i := 2; i <= 5; i++ {
+		p.Publish(i)
+	}
+	wg.Add(1)
+	p.Complete()
+	close(release)
+	wg.Wait()
+	assert.Equal(t, []int{1, 4, 5}, actualDropOldest)
+	assert.Equal(t, int64(2), sDropOldest.DroppedCount())
+	assert.True(t, isCompleted)
+
+	// The terminal delivery is never dropped by DropOldest(even if the values are offered after it)
+	buffer := newSubscriptionBuffer(1, BackpressureDropOldest)
+	bufferRelease := make(chan bool)
+	delivered := make(chan string, 10)
+	buffer.offer(func() {
+		<-bufferRelease
+		delivered <- "1"
+	})
+	assert.Eventually(t, func() bool {
+		return len(buffer.queue) == 0
+	}, time.Second, time.Millisecond)
+	buffer.offer(func() {
+		delivered <- "2"
+	})
+	buffer.putTerminal(func() {
+		delivered <- "completed"
+	})
+	buffer.offer(func() {
+		delivered <- "3"
+	})
+	close(bufferRelease)
+	assert.Equal(t, "1", <-delivered)
+	assert.Equal(t, "3", <-delivered)
+	assert.Equal(t, "completed", <-delivered)
+	assert.Equal(t, int64(1), atomic.LoadInt64(&buffer.droppedCount))
+
+	// Error
+	release = make(chan struct{})
+	p = PublisherNewGenerics[int]()
+	var actualError []int
+	isCompleted = false
+	sError := p.SubscribeWithBackpressure(slowSubscription(&actualError, &isCompleted, &actualErr), 2, BackpressureError)
+	p.Publish(1)
+	time.Sleep(5 * time.Millisecond)
+	wg.Add(1)
+	for i := 2; i <= 5; i++ {
+		p.Publish(i)
+	}
+	assert.Equal(t, 0, len(p.subscribers))
+	close(release)
+	wg.Wait()
+	assert.Equal(t, []int{1, 2, 3}, actualError)
+	assert.Equal(t, ErrSubscriptionBufferIsFull, actualErr)
+	assert.Equal(t, int64(1), sError.DroppedCount())
+	assert.False(t, isCompleted)
+
+	// Block
+	release = make(chan struct{})
+	p = PublisherNewGenerics[int]()
+	var actualBlock []int
+	isCompleted = false
+	sBlock := p.SubscribeWithBackpressure(slowSubscription(&actualBlock, &isCompleted, &actualErr), 1, BackpressureBlock)
+	isPublished := AtomBool{}
+	go func() {
+		for i := 1; i <= 3; i++ {
+			p.Publish(i)
+		}
+		isPublished.Set(true)
+	}()
+	time.Sleep(5 * time.Millisecond)
+	assert.False(t, isPublished.Get())
+	wg.Add(1)
+	close(release)
+	for !isPublished.Get() {
+		time.Sleep(time.Millisecond)
+	}
+	p.Complete()
+	wg.Wait()
+	assert.Equal(t, []int{1, 2, 3}, actualBlock)
+	assert.Equal(t, int64(0), sBlock.DroppedCount())
+
+	// Unsubscribe stops the buffer
+	p = PublisherNewGenerics[int]().SetBackpressure(1, BackpressureDropNewest)
+	s := p.Subscribe(Subscription[int]{})
+	p.Unsubscribe(s)
+	select {
+	case <-s.buffer.stopCh:
+	case <-time.After(time.Second):
+		assert.Fail(t, "the buffer should be stopped")
+	}
+}
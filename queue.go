@@ -1,6 +1,7 @@
 package fpgo
 
 import (
+	"container/heap"
 	"errors"
 	"sync"
 	"time"
@@ -499,6 +500,126 @@ func (q *LinkedListQueue[T]) recycleNode(node *DoublyListItem[T]) {
 	q.nodePoolFirst = node
 }
 
+// PriorityQueue
+
+type priorityQueueItem[T any] struct {
+	val T
+	seq uint64
+}
+
+type priorityQueueHeap[T any] struct {
+	items []priorityQueueItem[T]
+	less  func(a, b T) bool
+}
+
+func (h *priorityQueueHeap[T]) Len() int {
+	return len(h.items)
+}
+
+func (h *priorityQueueHeap[T]) Less(i, j int) bool {
+	if h.less(h.items[i].val, h.items[j].val) {
+		return true
+	}
+	if h.less(h.items[j].val, h.items[i].val) {
+		return false
+	}
+	// FIFO for the same priority
+	return h.items[i].seq < h.items[j].seq
+}
+
+func (h *priorityQueueHeap[T]) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+}
+
+func (h *priorityQueueHeap[T]) Push(x interface{}) {
+	h.items = append(h.items, x.(priorityQueueItem[T]))
+}
+
+func (h *priorityQueueHeap[T]) Pop() interface{} {
+	last := len(h.items) - 1
+	item := h.items[last]
+	h.items[last] = priorityQueueItem[T]{}
+	h.items = h.items[:last]
+	return item
+}
+
+// PriorityQueue PriorityQueue inspired by Collection utils(less(a, b) means a is taken before b, FIFO for the same priority)
+type PriorityQueue[T any] struct {
+	heap    priorityQueueHeap[T]
+	nextSeq uint64
+}
+
+// NewPriorityQueue New PriorityQueue instance with the less func
+func NewPriorityQueue[T any](less func(a, b T) bool) *PriorityQueue[T] {
+	return &PriorityQueue[T]{
+		heap: priorityQueueHeap[T]{less: less},
+	}
+}
+
+// Count Count items
+func (q *PriorityQueue[T]) Count() int {
+	return q.heap.Len()
+}
+
+// Put Put the T val(non-blocking)
+func (q *PriorityQueue[T]) Put(val T) error {
+	return q.Offer(val)
+}
+
+// Take Take the T val with the highest priority(non-blocking)
+func (q *PriorityQueue[T]) Take() (T, error) {
+	return q.Poll()
+}
+
+// Offer Offer the T val(non-blocking)
+func (q *PriorityQueue[T]) Offer(val T) error {
+	q.offerItem(priorityQueueItem[T]{val: val, seq: q.nextSeq})
+	q.nextSeq++
+	return nil
+}
+
+// Poll Poll the T val with the highest priority(non-blocking)
+func (q *PriorityQueue[T]) Poll() (T, error) {
+	item, err := q.pollItem()
+	return item.val, err
+}
+
+// Peek Peek the T val with the highest priority without removing it(non-blocking)
+func (q *PriorityQueue[T]) Peek() (T, error) {
+	if q.heap.Len() == 0 {
+		return *new(T), ErrQueueIsEmpty
+	}
+	return q.heap.items[0].val, nil
+}
+
+// removeIf Remove the T vals matched, returns the count of them
+func (q *PriorityQueue[T]) removeIf(match func(T) bool) int {
+	items := q.heap.items[:0]
+	for _, item := range q.heap.items {
+		if !match(item.val) {
+			items = append(items, item)
+		}
+	}
+	removed := len(q.heap.items) - len(items)
+	for i := len(items); i < len(q.heap.items); i++ {
+		q.heap.items[i] = priorityQueueItem[T]{}
+	}
+	q.heap.items = items
+	heap.Init(&q.heap)
+	return removed
+}
+
+func (q *PriorityQueue[T]) offerItem(item priorityQueueItem[T]) {
+	heap.Push(&q.heap, item)
+}
+
+func (q *PriorityQueue[T]) pollItem() (priorityQueueItem[T], error) {
+	if q.heap.Len() == 0 {
+		return priorityQueueItem[T]{}, ErrQueueIsEmpty
+	}
+	return heap.Pop(&q.heap).(priorityQueueItem[T]), nil
+}
+
 // BufferedChannelQueue BlockingQueue with ChannelQueue & scalable pool, inspired by Collection utils
 type BufferedChannelQueue[T any] struct {
 	lock     sync.RWMutex
@@ -563,6 +684,11 @@ func (q *BufferedChannelQueue[T]) loadFromPool() {
 		}
 
 		q.lock.Lock()
+		// Closed while waiting for the lock
+		if q.isClosed.Get() {
+			q.lock.Unlock()
+			break
+		}
 
 		var val T
 		var pollErr, offerErr error
@@ -589,6 +715,13 @@ func (q *BufferedChannelQueue[T]) loadFromPool() {
 }
 
 func (q *BufferedChannelQueue[T]) notifyWorkers() {
+	q.lock.RLock()
+	defer q.lock.RUnlock()
+	// The worker chans are closed
+	if q.isClosed.Get() {
+		return
+	}
+
 	q.loadWorkerCh.Offer(1)
 	q.freeNodeWorkerCh.Offer(1)
 }
@@ -668,6 +801,7 @@ func (q *BufferedChannelQueue[T]) Close() {
 
 	q.isClosed.Set(true)
 	close(q.loadWorkerCh)
+	close(q.freeNodeWorkerCh)
 	close(q.blockingQueue)
 }
 
@@ -375,3 +375,33 @@ func TestNewBufferedChannelQueue(t *testing.T) {
 	assert.GreaterOrEqual(t, bufferedChannelQueue.pool.nodeCount, 100)
 	close(asyncTaskDone)
 }
+
+func TestPriorityQueue(t *testing.T) {
+	var queue Queue[int]
+	var err error
+	var result int
+
+	priorityQueue := NewPriorityQueue(func(a, b int) bool {
+		return a > b
+	})
+	queue = priorityQueue
+
+	_, err = queue.Poll()
+	assert.Equal(t, ErrQueueIsEmpty, err)
+	for _, v := range []int{3, 1, 4, 1, 5} {
+		err = queue.Offer(v)
+		assert.Equal(t, nil, err)
+	}
+	assert.Equal(t, 5, priorityQueue.Count())
+	result, err = priorityQueue.Peek()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 5, result)
+
+	actual := []int{}
+	for priorityQueue.Count() > 0 {
+		result, err = queue.Take()
+		assert.Equal(t, nil, err)
+		actual = append(actual, result)
+	}
+	assert.Equal(t, []int{5, 4, 3, 1, 1}, actual)
+}
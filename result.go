@@ -0,0 +1,185 @@
+package fpgo
+
+import "errors"
+
+var (
+	// ErrResultErrIsNil The err of ResultErr is nil(it's the Err instead of an Ok of the zero value)
+	ErrResultErrIsNil = errors.New("result err is nil")
+	// ErrMaybeIsNone The Maybe of ResultFromMaybe is absent & the err is nil
+	ErrMaybeIsNone = errors.New("maybe is none")
+)
+
+// ResultDef[T] Result inspired by Rust/Scala Try, it's either an Ok value or an Err error
+type ResultDef[T any] struct {
+	value T
+	err   error
+}
+
+// ResultOk New an Ok Result
+func ResultOk[T any](value T) ResultDef[T] {
+	return ResultDef[T]{value: value}
+}
+
+// ResultErr New an Err Result(a nil err is replaced by ErrResultErrIsNil)
+func ResultErr[T any](err error) ResultDef[T] {
+	if err == nil {
+		err = ErrResultErrIsNil
+	}
+	return ResultDef[T]{err: err}
+}
+
+// ResultOf New a Result by (T, error)(Err if err is non-nil)
+func ResultOf[T any](value T, err error) ResultDef[T] {
+	if err != nil {
+		return ResultErr[T](err)
+	}
+	return ResultOk(value)
+}
+
+// ResultTry New a Result by the fn(the panic is an Err of PanicError)
+func ResultTry[T any](fn func() (T, error)) (result ResultDef[T]) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = ResultErr[T](NewPanicError(r))
+		}
+	}()
+
+	return ResultOf(fn())
+}
+
+// ResultFromMaybe New an Ok Result by the present Maybe, or an Err Result of err(ErrMaybeIsNone if err is nil)
+func ResultFromMaybe[T any](maybe MaybeDef[T], err error) ResultDef[T] {
+	if !maybe.IsPresent() {
+		if err == nil {
+			err = ErrMaybeIsNone
+		}
+		return ResultErr[T](err)
+	}
+	return ResultOk(maybe.Unwrap())
+}
+
+// ResultMap Map the Ok value to an another type by function(the Err is passed through)
+func ResultMap[T any, R any](resultSelf ResultDef[T], fn func(T) R) ResultDef[R] {
+	if resultSelf.IsErr() {
+		return ResultErr[R](resultSelf.err)
+	}
+	return ResultOk(fn(resultSelf.value))
+}
+
+// ResultFlatMap FlatMap the Ok value to an another Result by function(the Err is passed through)
+func ResultFlatMap[T any, R any](resultSelf ResultDef[T], fn func(T) ResultDef[R]) ResultDef[R] {
+	if resultSelf.IsErr() {
+		return ResultErr[R](resultSelf.err)
+	}
+	return fn(resultSelf.value)
+}
+
+// ResultSequence Collect the Ok values of the Results, or the first Err
+func ResultSequence[T any](results ...ResultDef[T]) ResultDef[[]T] {
+	values := make([]T, 0, len(results))
+	for _, result := range results {
+		if result.IsErr() {
+			return ResultErr[[]T](result.err)
+		}
+		values = append(values, result.value)
+	}
+	return ResultOk(values)
+}
+
+// ResultTraverse Map the values to Results by function & collect the Ok values, or stop at the first Err
+func ResultTraverse[T any, R any](fn func(T) ResultDef[R], values ...T) ResultDef[[]R] {
+	results := make([]R, 0, len(values))
+	for _, value := range values {
+		result := fn(value)
+		if result.IsErr() {
+			return ResultErr[[]R](result.err)
+		}
+		results = append(results, result.value)
+	}
+	return ResultOk(results)
+}
+
+// IsOk Is it Ok
+func (resultSelf ResultDef[T]) IsOk() bool {
+	return resultSelf.err == nil
+}
+
+// IsErr Is it Err
+func (resultSelf ResultDef[T]) IsErr() bool {
+	return resultSelf.err != nil
+}
+
+// Err Get the error(nil if it's Ok)
+func (resultSelf ResultDef[T]) Err() error {
+	return resultSelf.err
+}
+
+// Get Get it as (T, error)
+func (resultSelf ResultDef[T]) Get() (T, error) {
+	return resultSelf.value, resultSelf.err
+}
+
+// Unwrap Get the Ok value, it panics with the error if it's Err
+func (resultSelf ResultDef[T]) Unwrap() T {
+	if resultSelf.IsErr() {
+		panic(resultSelf.err)
+	}
+	return resultSelf.value
+}
+
+// UnwrapOr Get the Ok value, or the fallback value if it's Err
+func (resultSelf ResultDef[T]) UnwrapOr(or T) T {
+	if resultSelf.IsErr() {
+		return or
+	}
+	return resultSelf.value
+}
+
+// Map Map the Ok value by function(the Err is passed through)
+func (resultSelf ResultDef[T]) Map(fn func(T) T) ResultDef[T] {
+	return ResultMap(resultSelf, fn)
+}
+
+// FlatMap FlatMap the Ok value to an another Result by function(the Err is passed through)
+func (resultSelf ResultDef[T]) FlatMap(fn func(T) ResultDef[T]) ResultDef[T] {
+	return ResultFlatMap(resultSelf, fn)
+}
+
+// MapErr Map the error by function(the Ok value is passed through)
+func (resultSelf ResultDef[T]) MapErr(fn func(error) error) ResultDef[T] {
+	if resultSelf.IsOk() {
+		return resultSelf
+	}
+	return ResultErr[T](fn(resultSelf.err))
+}
+
+// OrElse Recover the Err to an another Result by function(the Ok value is passed through)
+func (resultSelf ResultDef[T]) OrElse(fn func(error) ResultDef[T]) ResultDef[T] {
+	if resultSelf.IsOk() {
+		return resultSelf
+	}
+	return fn(resultSelf.err)
+}
+
+// Let If it's Ok, then do the given function with the value
+func (resultSelf ResultDef[T]) Let(fn func(T)) {
+	if resultSelf.IsOk() {
+		fn(resultSelf.value)
+	}
+}
+
+// ToMaybe Ok to a Maybe of the value, Err to an absent Maybe
+func (resultSelf ResultDef[T]) ToMaybe() MaybeDef[T] {
+	if resultSelf.IsErr() {
+		return NoneOf[T]()
+	}
+	return JustGenerics(resultSelf.value)
+}
+
+// ToEither Ok to a Right, Err to a Left of the error
+func (resultSelf ResultDef[T]) ToEither() EitherDef[error, T] {
+	if resultSelf.IsErr() {
+		return EitherLeft[error, T](resultSelf.err)
+	}
+	return EitherRight[error](resultSelf.value)
+}
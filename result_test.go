@@ -0,0 +1,119 @@
+package fpgo
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResult(t *testing.T) {
+	errExpected := errors.New("expected")
+
+	// Map & FlatMap
+	result := ResultMap(ResultOf(strconv.Atoi("1")), func(v int) string {
+		return strconv.Itoa(v + 1)
+	})
+	assert.True(t, result.IsOk())
+	assert.Equal(t, "2", result.Unwrap())
+	failed := ResultOk(1).FlatMap(func(v int) ResultDef[int] {
+		return ResultErr[int](errExpected)
+	}).Map(func(v int) int {
+		return v + 1
+	})
+	assert.True(t, failed.IsErr())
+	assert.Equal(t, errExpected, failed.Err())
+	assert.Equal(t, 3, failed.UnwrapOr(3))
+	assert.PanicsWithError(t, errExpected.Error(), func() {
+		failed.Unwrap()
+	})
+
+	// MapErr & OrElse
+	wrapped := failed.MapErr(func(err error) error {
+		return errors.New("wrapped: " + err.Error())
+	})
+	assert.EqualError(t, wrapped.Err(), "wrapped: expected")
+	assert.Equal(t, 0, failed.OrElse(func(err error) ResultDef[int] {
+		return ResultOk(0)
+	}).Unwrap())
+	_, err := ResultOf(strconv.Atoi("x")).Get()
+	assert.Error(t, err)
+	assert.Equal(t, &PanicError{Value: "oops"}, ResultTry(func() (int, error) {
+		panic("oops")
+	}).Err())
+
+	// Maybe & Either
+	assert.Equal(t, 1, ResultOk(1).ToMaybe().Unwrap())
+	assert.False(t, failed.ToMaybe().IsPresent())
+	assert.Equal(t, 1, ResultFromMaybe(JustGenerics(1), errExpected).Unwrap())
+	assert.Equal(t, errExpected, ResultFromMaybe(failed.ToMaybe(), errExpected).Err())
+	// A nil err never makes an Ok of the zero value
+	absent := ResultFromMaybe(failed.ToMaybe(), nil)
+	assert.True(t, absent.IsErr())
+	assert.Equal(t, ErrMaybeIsNone, absent.Err())
+	assert.Equal(t, ErrResultErrIsNil, ResultErr[int](nil).Err())
+	assert.Equal(t, EitherLeft[error, int](errExpected), failed.ToEither())
+	assert.Equal(t, ResultOk(1), EitherToResult(ResultOk(1).ToEither()))
+
+	// Sequence & Traverse
+	assert.Equal(t, []int{1, 2}, ResultSequence(ResultOk(1), ResultOk(2)).Unwrap())
+	assert.Equal(t, errExpected, ResultSequence(ResultOk(1), failed).Err())
+	assert.Equal(t, []int{1, 2, 3}, ResultTraverse(func(v string) ResultDef[int] {
+		return ResultOf(strconv.Atoi(v))
+	}, "1", "2", "3").Unwrap())
+	assert.True(t, ResultTraverse(func(v string) ResultDef[int] {
+		return ResultOf(strconv.Atoi(v))
+	}, "1", "x", "3").IsErr())
+}
+
+func TestEither(t *testing.T) {
+	right := EitherRight[string](1)
+	left := EitherLeft[string, int]("left")
+
+	assert.True(t, right.IsRight())
+	assert.True(t, left.IsLeft())
+	assert.Equal(t, 2, right.Map(func(v int) int {
+		return v + 1
+	}).Unwrap())
+	assert.Equal(t, left, left.Map(func(v int) int {
+		return v + 1
+	}))
+	assert.Equal(t, "1", EitherMap(right, strconv.Itoa).Unwrap())
+	assert.Equal(t, "2", EitherFlatMap(right, func(v int) EitherDef[string, string] {
+		return EitherRight[string](strconv.Itoa(v + 1))
+	}).Unwrap())
+	value, ok := left.MapErr(func(v string) string {
+		return v + "!"
+	}).Left()
+	assert.True(t, ok)
+	assert.Equal(t, "left!", value)
+	assert.Equal(t, 0, left.OrElse(func(v string) EitherDef[string, int] {
+		return EitherRight[string](0)
+	}).Unwrap())
+	assert.Equal(t, 3, left.UnwrapOr(3))
+	assert.PanicsWithError(t, "either is left: left", func() {
+		left.Unwrap()
+	})
+	assert.Equal(t, "left", EitherFold(left, func(v string) string {
+		return v
+	}, strconv.Itoa))
+	assert.Equal(t, EitherRight[int]("left"), left.Swap())
+
+	// Maybe
+	assert.Equal(t, 1, right.ToMaybe().Unwrap())
+	assert.False(t, left.ToMaybe().IsPresent())
+	assert.Equal(t, right, EitherFromMaybe(JustGenerics(1), "left"))
+	assert.Equal(t, left, EitherFromMaybe(left.ToMaybe(), "left"))
+
+	// Sequence & Traverse
+	assert.Equal(t, []int{1, 1}, EitherSequence(right, right).Unwrap())
+	assert.Equal(t, EitherLeft[string, []int]("left"), EitherSequence(right, left))
+	assert.Equal(t, EitherLeft[string, []int]("x"), EitherTraverse(func(v string) EitherDef[string, int] {
+		i, err := strconv.Atoi(v)
+		if err != nil {
+			return EitherLeft[string, int](v)
+		}
+		return EitherRight[string](i)
+	}, "1", "x"))
+}
@@ -0,0 +1,138 @@
+package fpgo
+
+import (
+	"log"
+	"math"
+	"runtime"
+	"sync"
+)
+
+// Scheduler The executor of functions for SubscribeOn/ObserveOn(HandlerDef, worker.DefaultWorkerPool & the built-in ones)
+type Scheduler interface {
+	Schedule(fn func()) error
+}
+
+// SchedulerFunc A function as a Scheduler
+type SchedulerFunc func(fn func()) error
+
+// Schedule Schedule the function
+func (schedulerSelf SchedulerFunc) Schedule(fn func()) error {
+	return schedulerSelf(fn)
+}
+
+// SchedulerImmediate The Scheduler executing functions in the current goroutine at once
+var SchedulerImmediate Scheduler = SchedulerFunc(func(fn func()) error {
+	fn()
+	return nil
+})
+
+// SchedulerNewGoroutine The Scheduler executing every function in a new goroutine
+var SchedulerNewGoroutine Scheduler = SchedulerFunc(func(fn func()) error {
+	go fn()
+	return nil
+})
+
+// SchedulerSingleThreadNew New a Scheduler executing functions in order in its own goroutine(a new Handler)
+func SchedulerSingleThreadNew() *HandlerDef {
+	return Handler.New()
+}
+
+var computationScheduler *SchedulerPoolDef
+var computationSchedulerOnce sync.Once
+
+// SchedulerComputation Get the shared Scheduler of GOMAXPROCS goroutines for the CPU-bound works(started at the first call)
+func SchedulerComputation() *SchedulerPoolDef {
+	computationSchedulerOnce.Do(func() {
+		computationScheduler = SchedulerPoolNew(runtime.GOMAXPROCS(0))
+	})
+	return computationScheduler
+}
+
+var defaultSchedulerPoolPanicHandler = func(panic interface{}) {
+	log.Printf("panic from scheduler pool: %v\n", panic)
+	buf := make([]byte, 4096)
+	log.Printf("panic from scheduler pool: %s\n", string(buf[:runtime.Stack(buf, false)]))
+}
+
+// SchedulerPoolDef The Scheduler of a fixed number of goroutines sharing an unbounded queue(the order of the executions isn't kept)
+type SchedulerPoolDef struct {
+	size      int
+	queue     *BufferedChannelQueue[func()]
+	closeOnce sync.Once
+
+	lock         sync.Mutex
+	panicHandler func(interface{})
+}
+
+// SchedulerPoolNew New a Scheduler of size goroutines(at least 1)
+func SchedulerPoolNew(size int) *SchedulerPoolDef {
+	if size < 1 {
+		size = 1
+	}
+
+	newOne := &SchedulerPoolDef{
+		size:         size,
+		queue:        NewBufferedChannelQueue[func()](size, math.MaxInt, 16),
+		panicHandler: defaultSchedulerPoolPanicHandler,
+	}
+	for i := 0; i < size; i++ {
+		go newOne.run()
+	}
+
+	return newOne
+}
+
+// Schedule Schedule the function(ErrQueueIsClosed if it's closed)
+func (schedulerSelf *SchedulerPoolDef) Schedule(fn func()) error {
+	return schedulerSelf.queue.Offer(fn)
+}
+
+// GetSize Get the number of goroutines
+func (schedulerSelf *SchedulerPoolDef) GetSize() int {
+	return schedulerSelf.size
+}
+
+// SetPanicHandler Set the handler of the panics of the functions(the goroutine keeps running, it logs the panic & the stack by default)
+func (schedulerSelf *SchedulerPoolDef) SetPanicHandler(panicHandler func(interface{})) *SchedulerPoolDef {
+	schedulerSelf.lock.Lock()
+	defer schedulerSelf.lock.Unlock()
+
+	schedulerSelf.panicHandler = panicHandler
+	return schedulerSelf
+}
+
+// Close Close the Scheduler(the pending functions might not be executed)
+func (schedulerSelf *SchedulerPoolDef) Close() {
+	schedulerSelf.closeOnce.Do(schedulerSelf.queue.Close)
+}
+
+// IsClosed Is the Scheduler closed
+func (schedulerSelf *SchedulerPoolDef) IsClosed() bool {
+	return schedulerSelf.queue.IsClosed()
+}
+
+func (schedulerSelf *SchedulerPoolDef) run() {
+	for {
+		// GetChannel() loads the buffered ones into the channel
+		fn, ok := <-schedulerSelf.queue.GetChannel()
+		if !ok {
+			return
+		}
+		schedulerSelf.execute(fn)
+	}
+}
+
+func (schedulerSelf *SchedulerPoolDef) execute(fn func()) {
+	defer func() {
+		if panic := recover(); panic != nil {
+			schedulerSelf.lock.Lock()
+			panicHandler := schedulerSelf.panicHandler
+			schedulerSelf.lock.Unlock()
+			if panicHandler != nil {
+				panicHandler(panic)
+			}
+		}
+	}()
+
+	fn()
+}
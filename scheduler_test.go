@@ -0,0 +1,102 @@
+package fpgo
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchedulers(t *testing.T) {
+	var schedulers []Scheduler
+	handler := SchedulerSingleThreadNew()
+	pool := SchedulerPoolNew(4)
+	schedulers = append(schedulers, SchedulerImmediate, SchedulerNewGoroutine, handler, pool, SchedulerComputation())
+
+	for _, scheduler := range schedulers {
+		wg := sync.WaitGroup{}
+		var lock sync.Mutex
+		count := 0
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			assert.NoError(t, scheduler.Schedule(func() {
+				lock.Lock()
+				count++
+				lock.Unlock()
+				wg.Done()
+			}))
+		}
+		wg.Wait()
+		assert.Equal(t, 100, count)
+	}
+	assert.Equal(t, runtime.GOMAXPROCS(0), SchedulerComputation().GetSize())
+	assert.Equal(t, SchedulerComputation(), SchedulerComputation())
+
+	// The single thread one keeps the order
+	result := make(chan int, 10)
+	for i := 0; i < 10; i++ {
+		v := i
+		handler.Schedule(func() {
+			result <- v
+		})
+	}
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, i, <-result)
+	}
+
+	// The pool runs the functions concurrently
+	gate := make(chan bool)
+	for i := 0; i < 4; i++ {
+		pool.Schedule(func() {
+			gate <- true
+		})
+	}
+	for i := 0; i < 4; i++ {
+		select {
+		case <-gate:
+		case <-time.After(time.Second):
+			assert.Fail(t, "blocked")
+		}
+	}
+
+	// The panics are handled & the goroutines keep running
+	panics := make(chan interface{}, 4)
+	pool.SetPanicHandler(func(panic interface{}) {
+		panics <- panic
+	})
+	for i := 0; i < 4; i++ {
+		pool.Schedule(func() {
+			panic("pool panic")
+		})
+	}
+	for i := 0; i < 4; i++ {
+		assert.Equal(t, "pool panic", <-panics)
+	}
+	pool.Schedule(func() {
+		gate <- true
+	})
+	assert.True(t, <-gate)
+
+	// Closed
+	handler.Close()
+	pool.Close()
+	assert.Equal(t, ErrHandlerIsClosed, handler.Schedule(func() {}))
+	assert.Equal(t, ErrQueueIsClosed, pool.Schedule(func() {}))
+}
+
+func TestMonadIOObserveOnScheduler(t *testing.T) {
+	pool := SchedulerPoolNew(2)
+	defer pool.Close()
+
+	result := make(chan int, 1)
+	MonadIOMap(MonadIOJustGenerics(1), func(v int) int {
+		return v + 1
+	}).ObserveOn(pool).SubscribeOn(SchedulerNewGoroutine).Subscribe(Subscription[int]{
+		OnNext: func(v int) {
+			result <- v
+		},
+	})
+	assert.Equal(t, 2, <-result)
+}
@@ -158,3 +158,18 @@ func TestWorkerJamDuration(t *testing.T) {
 	// A new expected goroutine is generated
 	assert.Equal(t, 5, defaultWorkerPool.workerCount)
 }
+
+func TestWorkerPoolAsScheduler(t *testing.T) {
+	var scheduler fpgo.Scheduler
+	defaultWorkerPool := NewDefaultWorkerPool(fpgo.NewBufferedChannelQueue[func()](3, 10000, 100), nil)
+	defer defaultWorkerPool.Close()
+	scheduler = defaultWorkerPool
+
+	result := make(chan int, 1)
+	fpgo.MonadIOJustGenerics(1).ObserveOn(scheduler).Subscribe(fpgo.Subscription[int]{
+		OnNext: func(v int) {
+			result <- v
+		},
+	})
+	assert.Equal(t, 1, <-result)
+}